@@ -0,0 +1,632 @@
+package ladybug
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+)
+
+// DecodeRecord appends the rows of rec, as returned by Result.NextRecord, to dst as values of
+// type T and returns the extended slice. Columns are decoded column by column straight from
+// the Arrow buffers, without calling into the library per value.
+//
+// T follows the rules of QueryAll: structs (or pointers to structs) are filled field by field
+// using `lbug:"name"` tags or case-insensitive field names, and any other T requires a
+// single-column record. NODE and REL columns are Arrow structs of their properties plus the
+// internal fields "_ID" and "_LABEL" (and "_SRC" and "_DST" for REL); tag a field with one of
+// these names to read it. Timestamps are decoded in UTC.
+func DecodeRecord[T any](rec arrow.Record, dst []T) ([]T, error) {
+	d, err := newRecordDecoder[T](rec.Schema(), time.UTC)
+	if err != nil {
+		return dst, err
+	}
+	return d.decode(rec, dst)
+}
+
+// DecodeAll reads the remaining rows of res with NextRecord(chunkSize) and decodes them with
+// DecodeRecord, rendering timestamps in the location of res. Prefer it over ScanAll and
+// QueryAll for large results.
+func DecodeAll[T any](res *Result, chunkSize int64) ([]T, error) {
+	if res == nil || res.c == nil {
+		return nil, ErrClosed
+	}
+	var out []T
+	var d *recordDecoder[T]
+	for rec, err := range res.Records(chunkSize) {
+		if err != nil {
+			return out, err
+		}
+		if d == nil || !d.schema.Equal(rec.Schema()) {
+			if d, err = newRecordDecoder[T](rec.Schema(), res.location()); err != nil {
+				return out, err
+			}
+		}
+		if out, err = d.decode(rec, out); err != nil {
+			return out, err
+		}
+	}
+	return out, nil
+}
+
+// arrowSetter stores element i of arr into dst. The array is the concrete type the setter
+// was compiled for.
+type arrowSetter func(arr arrow.Array, i int, dst reflect.Value) error
+
+type columnSetter struct {
+	col   int
+	name  string
+	index []int // field path into the struct; nil to set the whole element
+	set   arrowSetter
+}
+
+// recordDecoder holds the setters compiled for one schema and element type.
+type recordDecoder[T any] struct {
+	schema  *arrow.Schema
+	setters []columnSetter
+	// whole decodes the only (struct) column into the element itself.
+	whole bool
+}
+
+// newRecordDecoder compiles the setters decoding records of schema sc into T, rendering
+// timestamps in loc.
+func newRecordDecoder[T any](sc *arrow.Schema, loc *time.Location) (*recordDecoder[T], error) {
+	t := reflect.TypeFor[T]()
+	st := t
+	if st.Kind() == reflect.Pointer {
+		st = st.Elem()
+	}
+	d := &recordDecoder[T]{schema: sc}
+	if !decodesAsStruct(st) {
+		if sc.NumFields() != 1 {
+			return nil, fmt.Errorf("ladybug: cannot decode %d columns into %s; use a struct type", sc.NumFields(), t)
+		}
+		set, err := compileArrowSetter(sc.Field(0).Type, t, loc)
+		if err != nil {
+			return nil, fmt.Errorf("ladybug: column %q: %w", sc.Field(0).Name, err)
+		}
+		d.setters = []columnSetter{{col: 0, name: sc.Field(0).Name, set: set}}
+		return d, nil
+	}
+	fields := cachedStructFields(st)
+	for i, f := range sc.Fields() {
+		sf := matchField(fields, f.Name)
+		if sf == nil {
+			continue
+		}
+		set, err := compileArrowSetter(f.Type, sf.typ, loc)
+		if err != nil {
+			return nil, fmt.Errorf("ladybug: column %q into field %s: %w", f.Name, sf.name, err)
+		}
+		d.setters = append(d.setters, columnSetter{col: i, name: f.Name, index: sf.index, set: set})
+	}
+	if len(d.setters) == 0 && sc.NumFields() == 1 {
+		if _, ok := sc.Field(0).Type.(*arrow.StructType); ok {
+			set, err := compileArrowSetter(sc.Field(0).Type, st, loc)
+			if err != nil {
+				return nil, fmt.Errorf("ladybug: column %q: %w", sc.Field(0).Name, err)
+			}
+			d.setters = []columnSetter{{col: 0, name: sc.Field(0).Name, set: set}}
+			d.whole = true
+		}
+	}
+	return d, nil
+}
+
+func (d *recordDecoder[T]) decode(rec arrow.Record, dst []T) ([]T, error) {
+	n := int(rec.NumRows())
+	base := len(dst)
+	var zero T
+	for i := 0; i < n; i++ {
+		dst = append(dst, zero)
+	}
+	elems := reflect.ValueOf(dst[base:])
+	isPtr := elems.Type().Elem().Kind() == reflect.Pointer && decodesAsStruct(elems.Type().Elem().Elem())
+	if isPtr {
+		for i := 0; i < n; i++ {
+			elems.Index(i).Set(reflect.New(elems.Type().Elem().Elem()))
+		}
+	}
+	for _, cs := range d.setters {
+		arr := rec.Column(cs.col)
+		for i := 0; i < n; i++ {
+			target := elems.Index(i)
+			if isPtr {
+				target = target.Elem()
+			}
+			var err error
+			if cs.index != nil {
+				target, err = fieldByIndexAlloc(target, cs.index)
+			}
+			if err == nil {
+				err = cs.set(arr, i, target)
+			}
+			if err != nil {
+				return dst[:base], fmt.Errorf("ladybug: column %q row %d: %w", cs.name, i, err)
+			}
+		}
+	}
+	return dst, nil
+}
+
+// compileArrowSetter returns a setter converting elements of Arrow type dt into Go type t,
+// rendering timestamps in loc, or an error if the types cannot be converted.
+func compileArrowSetter(dt arrow.DataType, t reflect.Type, loc *time.Location) (arrowSetter, error) {
+	if reflect.PointerTo(t).Implements(scannerType) && t != timestampType || t.Kind() == reflect.Interface || t == nodeType || t == relType || t == pathType || t == idType || t == bigIntType {
+		return genericSetter(loc), nil
+	}
+	if t.Kind() == reflect.Pointer {
+		elem, err := compileArrowSetter(dt, t.Elem(), loc)
+		if err != nil {
+			return nil, err
+		}
+		return func(arr arrow.Array, i int, dst reflect.Value) error {
+			if arr.IsNull(i) {
+				dst.SetZero()
+				return nil
+			}
+			p := reflect.New(t.Elem())
+			if err := elem(arr, i, p.Elem()); err != nil {
+				return err
+			}
+			dst.Set(p)
+			return nil
+		}, nil
+	}
+	set, err := compileValueSetter(dt, t, loc)
+	if err != nil {
+		return nil, err
+	}
+	return func(arr arrow.Array, i int, dst reflect.Value) error {
+		if arr.IsNull(i) {
+			dst.SetZero()
+			return nil
+		}
+		return set(arr, i, dst)
+	}, nil
+}
+
+// arrowPrecision returns the Ladybug timestamp type an Arrow timestamp type comes from.
+func arrowPrecision(dt *arrow.TimestampType) TimestampPrecision {
+	switch dt.Unit {
+	case arrow.Second:
+		return TimestampSec
+	case arrow.Millisecond:
+		return TimestampMillis
+	case arrow.Nanosecond:
+		return TimestampNanos
+	}
+	if dt.TimeZone != "" {
+		return TimestampTZ
+	}
+	return TimestampMicros
+}
+
+func isIntKind(k reflect.Kind) bool   { return k >= reflect.Int && k <= reflect.Int64 }
+func isUintKind(k reflect.Kind) bool  { return k >= reflect.Uint && k <= reflect.Uintptr }
+func isFloatKind(k reflect.Kind) bool { return k == reflect.Float32 || k == reflect.Float64 }
+
+func isNumericKind(k reflect.Kind) bool {
+	return isIntKind(k) || isUintKind(k) || isFloatKind(k)
+}
+
+// compileValueSetter is compileArrowSetter for non-null elements.
+func compileValueSetter(dt arrow.DataType, t reflect.Type, loc *time.Location) (arrowSetter, error) {
+	mismatch := fmt.Errorf("cannot decode %s into %s", dt, t)
+	k := t.Kind()
+	switch dt := dt.(type) {
+	case *arrow.Int8Type, *arrow.Int16Type, *arrow.Int32Type, *arrow.Int64Type:
+		if !isNumericKind(k) {
+			return nil, mismatch
+		}
+		return func(arr arrow.Array, i int, dst reflect.Value) error {
+			return assignInt(dst, arrowInt(arr, i))
+		}, nil
+	case *arrow.Uint8Type, *arrow.Uint16Type, *arrow.Uint32Type, *arrow.Uint64Type:
+		if !isNumericKind(k) {
+			return nil, mismatch
+		}
+		return func(arr arrow.Array, i int, dst reflect.Value) error {
+			return assignUint64(dst, arrowUint(arr, i))
+		}, nil
+	case *arrow.Float32Type:
+		if !isFloatKind(k) {
+			return nil, mismatch
+		}
+		return func(arr arrow.Array, i int, dst reflect.Value) error {
+			dst.SetFloat(float64(arr.(*array.Float32).Value(i)))
+			return nil
+		}, nil
+	case *arrow.Float64Type:
+		if !isFloatKind(k) {
+			return nil, mismatch
+		}
+		return func(arr arrow.Array, i int, dst reflect.Value) error {
+			dst.SetFloat(arr.(*array.Float64).Value(i))
+			return nil
+		}, nil
+	case *arrow.BooleanType:
+		if k != reflect.Bool {
+			return nil, mismatch
+		}
+		return func(arr arrow.Array, i int, dst reflect.Value) error {
+			dst.SetBool(arr.(*array.Boolean).Value(i))
+			return nil
+		}, nil
+	case *arrow.StringType:
+		if k != reflect.String {
+			return nil, mismatch
+		}
+		return func(arr arrow.Array, i int, dst reflect.Value) error {
+			dst.SetString(arr.(*array.String).Value(i))
+			return nil
+		}, nil
+	case *arrow.LargeStringType:
+		if k != reflect.String {
+			return nil, mismatch
+		}
+		return func(arr arrow.Array, i int, dst reflect.Value) error {
+			dst.SetString(arr.(*array.LargeString).Value(i))
+			return nil
+		}, nil
+	case *arrow.BinaryType, *arrow.LargeBinaryType:
+		if k != reflect.Slice || t.Elem().Kind() != reflect.Uint8 {
+			return nil, mismatch
+		}
+		return func(arr arrow.Array, i int, dst reflect.Value) error {
+			var b []byte
+			if a, ok := arr.(*array.Binary); ok {
+				b = a.Value(i)
+			} else {
+				b = arr.(*array.LargeBinary).Value(i)
+			}
+			dst.SetBytes(append([]byte(nil), b...))
+			return nil
+		}, nil
+	case *arrow.TimestampType:
+		unit := dt.Unit
+		switch t {
+		case timeType:
+			return func(arr arrow.Array, i int, dst reflect.Value) error {
+				dst.Set(reflect.ValueOf(arr.(*array.Timestamp).Value(i).ToTime(unit).In(loc)))
+				return nil
+			}, nil
+		case timestampType:
+			prec := arrowPrecision(dt)
+			return func(arr arrow.Array, i int, dst reflect.Value) error {
+				ts := Timestamp{Time: arr.(*array.Timestamp).Value(i).ToTime(unit).In(loc), Precision: prec}
+				dst.Set(reflect.ValueOf(ts))
+				return nil
+			}, nil
+		}
+		return nil, mismatch
+	case *arrow.Date32Type:
+		if t != timeType {
+			return nil, mismatch
+		}
+		return func(arr arrow.Array, i int, dst reflect.Value) error {
+			dst.Set(reflect.ValueOf(arr.(*array.Date32).Value(i).ToTime()))
+			return nil
+		}, nil
+	case *arrow.Date64Type:
+		if t != timeType {
+			return nil, mismatch
+		}
+		return func(arr arrow.Array, i int, dst reflect.Value) error {
+			dst.Set(reflect.ValueOf(arr.(*array.Date64).Value(i).ToTime()))
+			return nil
+		}, nil
+	case *arrow.DurationType, *arrow.MonthDayNanoIntervalType:
+		if t != durationType {
+			return nil, mismatch
+		}
+		return func(arr arrow.Array, i int, dst reflect.Value) error {
+			dst.SetInt(int64(arrowValue(arr, i, loc).(Interval).ApproxDuration()))
+			return nil
+		}, nil
+	case *arrow.ListType, *arrow.LargeListType, *arrow.FixedSizeListType:
+		return compileListSetter(dt.(arrow.ListLikeType).Elem(), t, loc)
+	case *arrow.MapType:
+		if k != reflect.Map {
+			return nil, mismatch
+		}
+		keySet, err := compileArrowSetter(dt.KeyType(), t.Key(), loc)
+		if err != nil {
+			return nil, err
+		}
+		itemSet, err := compileArrowSetter(dt.ItemType(), t.Elem(), loc)
+		if err != nil {
+			return nil, err
+		}
+		return func(arr arrow.Array, i int, dst reflect.Value) error {
+			a := arr.(*array.Map)
+			start, end := a.ValueOffsets(i)
+			m := reflect.MakeMapWithSize(t, int(end-start))
+			for j := int(start); j < int(end); j++ {
+				kv := reflect.New(t.Key()).Elem()
+				if err := keySet(a.Keys(), j, kv); err != nil {
+					return err
+				}
+				iv := reflect.New(t.Elem()).Elem()
+				if err := itemSet(a.Items(), j, iv); err != nil {
+					return err
+				}
+				m.SetMapIndex(kv, iv)
+			}
+			dst.Set(m)
+			return nil
+		}, nil
+	case *arrow.StructType:
+		switch {
+		case decodesAsStruct(t):
+			return compileStructSetter(dt, t, loc)
+		case k == reflect.Map && t.Key().Kind() == reflect.String:
+			return compileStructMapSetter(dt, t, loc)
+		}
+		return nil, mismatch
+	}
+	// Remaining types (decimals, unions, ...) go through their generic Go value.
+	return genericSetter(loc), nil
+}
+
+func compileListSetter(elemType arrow.DataType, t reflect.Type, loc *time.Location) (arrowSetter, error) {
+	if t.Kind() != reflect.Slice && t.Kind() != reflect.Array {
+		return nil, fmt.Errorf("cannot decode list into %s", t)
+	}
+	elem, err := compileArrowSetter(elemType, t.Elem(), loc)
+	if err != nil {
+		return nil, err
+	}
+	return func(arr arrow.Array, i int, dst reflect.Value) error {
+		a := arr.(array.ListLike)
+		start, end := a.ValueOffsets(i)
+		n := int(end - start)
+		out := dst
+		if t.Kind() == reflect.Slice {
+			out = reflect.MakeSlice(t, n, n)
+		} else if n != t.Len() {
+			return fmt.Errorf("cannot decode %d elements into %s", n, t)
+		}
+		values := a.ListValues()
+		for j := 0; j < n; j++ {
+			if err := elem(values, int(start)+j, out.Index(j)); err != nil {
+				return fmt.Errorf("[%d]: %w", j, err)
+			}
+		}
+		if t.Kind() == reflect.Slice {
+			dst.Set(out)
+		}
+		return nil
+	}, nil
+}
+
+func compileStructSetter(dt *arrow.StructType, t reflect.Type, loc *time.Location) (arrowSetter, error) {
+	type child struct {
+		field int
+		index []int
+		set   arrowSetter
+	}
+	var children []child
+	arrowFields := dt.Fields()
+	for _, f := range cachedStructFields(t) {
+		j := -1
+		for k, af := range arrowFields {
+			if af.Name == f.name {
+				j = k
+				break
+			}
+		}
+		if j < 0 {
+			for k, af := range arrowFields {
+				if strings.EqualFold(af.Name, f.name) {
+					j = k
+					break
+				}
+			}
+		}
+		if j < 0 {
+			continue
+		}
+		set, err := compileArrowSetter(arrowFields[j].Type, f.typ, loc)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", f.name, err)
+		}
+		children = append(children, child{field: j, index: f.index, set: set})
+	}
+	return func(arr arrow.Array, i int, dst reflect.Value) error {
+		a := arr.(*array.Struct)
+		for _, c := range children {
+			fv, err := fieldByIndexAlloc(dst, c.index)
+			if err == nil {
+				err = c.set(a.Field(c.field), i, fv)
+			}
+			if err != nil {
+				return fmt.Errorf("%s: %w", arrowFields[c.field].Name, err)
+			}
+		}
+		return nil
+	}, nil
+}
+
+func compileStructMapSetter(dt *arrow.StructType, t reflect.Type, loc *time.Location) (arrowSetter, error) {
+	fields := dt.Fields()
+	sets := make([]arrowSetter, len(fields))
+	for j, f := range fields {
+		set, err := compileArrowSetter(f.Type, t.Elem(), loc)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", f.Name, err)
+		}
+		sets[j] = set
+	}
+	return func(arr arrow.Array, i int, dst reflect.Value) error {
+		a := arr.(*array.Struct)
+		m := reflect.MakeMapWithSize(t, len(fields))
+		for j, f := range fields {
+			v := reflect.New(t.Elem()).Elem()
+			if err := sets[j](a.Field(j), i, v); err != nil {
+				return fmt.Errorf("%s: %w", f.Name, err)
+			}
+			m.SetMapIndex(reflect.ValueOf(f.Name).Convert(t.Key()), v)
+		}
+		dst.Set(m)
+		return nil
+	}, nil
+}
+
+// genericSetter returns a setter converting through the same Go values Row.Value returns.
+func genericSetter(loc *time.Location) arrowSetter {
+	return func(arr arrow.Array, i int, dst reflect.Value) error {
+		return assignValue(dst, arrowValue(arr, i, loc))
+	}
+}
+
+func arrowInt(arr arrow.Array, i int) int64 {
+	switch a := arr.(type) {
+	case *array.Int8:
+		return int64(a.Value(i))
+	case *array.Int16:
+		return int64(a.Value(i))
+	case *array.Int32:
+		return int64(a.Value(i))
+	default:
+		return a.(*array.Int64).Value(i)
+	}
+}
+
+func arrowUint(arr arrow.Array, i int) uint64 {
+	switch a := arr.(type) {
+	case *array.Uint8:
+		return uint64(a.Value(i))
+	case *array.Uint16:
+		return uint64(a.Value(i))
+	case *array.Uint32:
+		return uint64(a.Value(i))
+	default:
+		return a.(*array.Uint64).Value(i)
+	}
+}
+
+// arrowValue returns element i of arr as the Go value Row.Value would produce: int64, uint64,
+// float64, bool, string, []byte, time.Time (in loc), Date, Interval, []any or map[string]any.
+// NODE and REL structs are returned in the map shape AsNode and AsRel accept.
+func arrowValue(arr arrow.Array, i int, loc *time.Location) any {
+	if arr.IsNull(i) {
+		return nil
+	}
+	switch a := arr.(type) {
+	case *array.Int8, *array.Int16, *array.Int32, *array.Int64:
+		return arrowInt(a, i)
+	case *array.Uint8, *array.Uint16, *array.Uint32, *array.Uint64:
+		return arrowUint(a, i)
+	case *array.Float32:
+		return float64(a.Value(i))
+	case *array.Float64:
+		return a.Value(i)
+	case *array.Boolean:
+		return a.Value(i)
+	case *array.String:
+		return a.Value(i)
+	case *array.LargeString:
+		return a.Value(i)
+	case *array.Binary:
+		return append([]byte(nil), a.Value(i)...)
+	case *array.LargeBinary:
+		return append([]byte(nil), a.Value(i)...)
+	case *array.Decimal128:
+		return Decimal{Unscaled: a.Value(i).BigInt(), Scale: a.DataType().(*arrow.Decimal128Type).Scale}
+	case *array.Decimal256:
+		return Decimal{Unscaled: a.Value(i).BigInt(), Scale: a.DataType().(*arrow.Decimal256Type).Scale}
+	case *array.Timestamp:
+		return a.Value(i).ToTime(a.DataType().(*arrow.TimestampType).Unit).In(loc)
+	case *array.Date32:
+		return dateFromDays(int64(a.Value(i)))
+	case *array.Date64:
+		return DateOf(a.Value(i).ToTime())
+	case *array.Duration:
+		unit := a.DataType().(*arrow.DurationType).Unit
+		return IntervalFromDuration(time.Duration(a.Value(i)) * unit.Multiplier())
+	case *array.MonthDayNanoInterval:
+		v := a.Value(i)
+		return Interval{Months: v.Months, Days: v.Days, Micros: v.Nanoseconds / 1000}
+	case *array.Map:
+		start, end := a.ValueOffsets(i)
+		m := make(map[string]any, end-start)
+		for j := int(start); j < int(end); j++ {
+			m[fmt.Sprint(arrowValue(a.Keys(), j, loc))] = arrowValue(a.Items(), j, loc)
+		}
+		return m
+	case array.ListLike:
+		start, end := a.ValueOffsets(i)
+		out := make([]any, 0, end-start)
+		for j := int(start); j < int(end); j++ {
+			out = append(out, arrowValue(a.ListValues(), j, loc))
+		}
+		return out
+	case *array.Struct:
+		fields := a.DataType().(*arrow.StructType).Fields()
+		m := make(map[string]any, len(fields))
+		for j, f := range fields {
+			m[f.Name] = arrowValue(a.Field(j), i, loc)
+		}
+		return graphValue(m)
+	}
+	return arr.ValueStr(i)
+}
+
+// graphValue reshapes the Arrow struct of a NODE, REL or RECURSIVE_REL into the map layout
+// produced by Row.Value ("id", "labels" or "label", "src_id", "dst_id", "properties"; "nodes"
+// and "rels"). Other structs are returned unchanged.
+func graphValue(m map[string]any) map[string]any {
+	if nodes, ok := m["_NODES"]; ok && len(m) == 2 {
+		if rels, ok := m["_RELS"]; ok {
+			return map[string]any{"nodes": nodes, "rels": rels}
+		}
+	}
+	id, hasID := m["_ID"]
+	label, hasLabel := m["_LABEL"]
+	if !hasID || !hasLabel {
+		return m
+	}
+	props := make(map[string]any, len(m))
+	for k, v := range m {
+		if !strings.HasPrefix(k, "_") {
+			props[k] = v
+		}
+	}
+	out := map[string]any{"id": arrowInternalID(id), "properties": props}
+	if src, ok := m["_SRC"]; ok {
+		out["src_id"], out["dst_id"], out["label"] = arrowInternalID(src), arrowInternalID(m["_DST"]), label
+		return out
+	}
+	if s, ok := label.(string); ok {
+		out["labels"] = []any{s}
+	}
+	return out
+}
+
+// arrowInternalID converts the {offset, table} struct of an Arrow INTERNAL_ID into an InternalID.
+func arrowInternalID(v any) any {
+	if id, ok := asInternalID(v); ok {
+		return id
+	}
+	return v
+}
+
+// assignUint64 stores u into a numeric dst, checking for overflow.
+func assignUint64(dst reflect.Value, u uint64) error {
+	if u <= math.MaxInt64 {
+		return assignInt(dst, int64(u))
+	}
+	switch k := dst.Kind(); {
+	case isUintKind(k):
+		return assignUint(dst, u)
+	case isFloatKind(k):
+		dst.SetFloat(float64(u))
+		return nil
+	}
+	return fmt.Errorf("value %d overflows %s", u, dst.Type())
+}
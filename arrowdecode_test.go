@@ -0,0 +1,176 @@
+package ladybug
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+)
+
+// testPersonRecord builds a record shaped like `RETURN p, p.name AS name, ...` output:
+// a NODE struct column plus scalar, list and timestamp columns.
+func testPersonRecord(t *testing.T) arrow.Record {
+	t.Helper()
+	nodeType := arrow.StructOf(
+		arrow.Field{Name: "_ID", Type: arrow.StructOf(
+			arrow.Field{Name: "offset", Type: arrow.PrimitiveTypes.Int64},
+			arrow.Field{Name: "table", Type: arrow.PrimitiveTypes.Int64},
+		)},
+		arrow.Field{Name: "_LABEL", Type: arrow.BinaryTypes.String},
+		arrow.Field{Name: "name", Type: arrow.BinaryTypes.String},
+		arrow.Field{Name: "age", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+	)
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "p", Type: nodeType},
+		{Name: "score", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+		{Name: "tags", Type: arrow.ListOf(arrow.BinaryTypes.String)},
+		{Name: "since", Type: &arrow.TimestampType{Unit: arrow.Microsecond}},
+	}, nil)
+	b := array.NewRecordBuilder(memory.NewGoAllocator(), schema)
+	defer b.Release()
+
+	node := b.Field(0).(*array.StructBuilder)
+	id := node.FieldBuilder(0).(*array.StructBuilder)
+	for i, name := range []string{"ann", "bob"} {
+		node.Append(true)
+		id.Append(true)
+		id.FieldBuilder(0).(*array.Int64Builder).Append(int64(i))
+		id.FieldBuilder(1).(*array.Int64Builder).Append(0)
+		node.FieldBuilder(1).(*array.StringBuilder).Append("Person")
+		node.FieldBuilder(2).(*array.StringBuilder).Append(name)
+		if i == 0 {
+			node.FieldBuilder(3).(*array.Int64Builder).Append(30)
+		} else {
+			node.FieldBuilder(3).(*array.Int64Builder).AppendNull()
+		}
+	}
+	b.Field(1).(*array.Float64Builder).AppendValues([]float64{1.5, 0}, []bool{true, false})
+	tags := b.Field(2).(*array.ListBuilder)
+	tags.Append(true)
+	tags.ValueBuilder().(*array.StringBuilder).AppendValues([]string{"a", "b"}, nil)
+	tags.Append(true)
+	ts := b.Field(3).(*array.TimestampBuilder)
+	ts.Append(arrow.Timestamp(time.Date(2020, 1, 2, 3, 4, 5, 6000, time.UTC).UnixMicro()))
+	ts.Append(0)
+	return b.NewRecord()
+}
+
+func TestDecodeRecord(t *testing.T) {
+	rec := testPersonRecord(t)
+	defer rec.Release()
+
+	type person struct {
+		Name string `lbug:"name"`
+		Age  *int32 `lbug:"age"`
+	}
+	type row struct {
+		P     person
+		Score *float64
+		Tags  []string
+		Since time.Time
+	}
+	rows, err := DecodeRecord[row](rec, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("got %d rows, want 2", len(rows))
+	}
+	r0, r1 := rows[0], rows[1]
+	if r0.P.Name != "ann" || r0.P.Age == nil || *r0.P.Age != 30 || r1.P.Age != nil {
+		t.Errorf("node properties: %+v %+v", r0.P, r1.P)
+	}
+	if r0.Score == nil || *r0.Score != 1.5 || r1.Score != nil {
+		t.Errorf("score: %v %v", r0.Score, r1.Score)
+	}
+	if len(r0.Tags) != 2 || r0.Tags[1] != "b" || len(r1.Tags) != 0 {
+		t.Errorf("tags: %v %v", r0.Tags, r1.Tags)
+	}
+	if want := time.Date(2020, 1, 2, 3, 4, 5, 6000, time.UTC); !r0.Since.Equal(want) || r0.Since.Location() != time.UTC {
+		t.Errorf("since = %v, want %v", r0.Since, want)
+	}
+
+	// A single struct column decodes into the element itself.
+	one := rec.NewSlice(0, 2)
+	defer one.Release()
+	proj := array.NewRecord(arrow.NewSchema(rec.Schema().Fields()[:1], nil), one.Columns()[:1], 2)
+	defer proj.Release()
+	people, err := DecodeRecord[*person](proj, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(people) != 2 || people[1].Name != "bob" {
+		t.Errorf("whole-struct decode: %+v", people)
+	}
+
+	nodes, err := DecodeRecord[Node](proj, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(nodes) != 2 || nodes[0].Labels[0] != "Person" || nodes[0].Properties["name"] != "ann" {
+		t.Errorf("Node decode: %+v", nodes)
+	}
+
+	if _, err := DecodeRecord[int64](rec, nil); err == nil {
+		t.Error("scalar decode of 4 columns: want error")
+	}
+	type tagged struct {
+		Tags []string `lbug:"tags"`
+	}
+	type embedded struct{ *tagged }
+	if _, err := DecodeRecord[embedded](rec, nil); err == nil {
+		t.Error("nil unexported embedded pointer: want error")
+	}
+	type bad struct {
+		Tags int `lbug:"tags"`
+	}
+	if _, err := DecodeRecord[bad](rec, nil); err == nil {
+		t.Error("list into int: want error")
+	}
+}
+
+func TestDecodeLocation(t *testing.T) {
+	rec := testPersonRecord(t)
+	defer rec.Release()
+	tokyo := time.FixedZone("JST", 9*3600)
+	want := time.Date(2020, 1, 2, 3, 4, 5, 6000, time.UTC)
+
+	type plain struct{ Since time.Time }
+	type typed struct{ Since Timestamp }
+	type generic struct{ Since any }
+	pd, err := newRecordDecoder[plain](rec.Schema(), tokyo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	td, err := newRecordDecoder[typed](rec.Schema(), tokyo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gd, err := newRecordDecoder[generic](rec.Schema(), tokyo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := pd.decode(rec, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts, err := td.decode(rec, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, err := gd.decode(rec, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := p[0].Since; got.Location() != tokyo || !got.Equal(want) {
+		t.Errorf("time.Time = %v", got)
+	}
+	if got := ts[0].Since; got.Time.Location() != tokyo || !got.Time.Equal(want) || got.Precision != TimestampMicros {
+		t.Errorf("Timestamp = %+v", got)
+	}
+	if got, ok := g[0].Since.(time.Time); !ok || got.Location() != tokyo || !got.Equal(want) {
+		t.Errorf("any = %#v", g[0].Since)
+	}
+}
@@ -0,0 +1,258 @@
+package ladybug
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+)
+
+// recordFromSlice converts a slice of structs (or pointers to structs) into one Arrow
+// record with a column per field, named as PreparedStatement.Bind names struct fields.
+func recordFromSlice[T any](rows []T) (arrow.RecordBatch, error) {
+	t := reflect.TypeFor[T]()
+	st := t
+	if st.Kind() == reflect.Pointer {
+		st = st.Elem()
+	}
+	if st.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("ladybug: bulk load needs a slice of structs (got []%s)", t)
+	}
+	fields := cachedStructFields(st)
+	rv := reflect.ValueOf(rows)
+	afs := make([]arrow.Field, len(fields))
+	for i, f := range fields {
+		dt, err := arrowTypeOf(f.typ)
+		if err == nil && (f.typ == timestampType || f.typ == reflect.PointerTo(timestampType)) {
+			dt, err = timestampColumnType(rv, f)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("ladybug: field %s: %w", f.name, err)
+		}
+		afs[i] = arrow.Field{Name: f.name, Type: dt, Nullable: true}
+	}
+	b := array.NewRecordBuilder(memory.DefaultAllocator, arrow.NewSchema(afs, nil))
+	defer b.Release()
+	for i := 0; i < rv.Len(); i++ {
+		elem := rv.Index(i)
+		if elem.Kind() == reflect.Pointer {
+			if elem.IsNil() {
+				return nil, fmt.Errorf("ladybug: row %d is nil", i)
+			}
+			elem = elem.Elem()
+		}
+		for j, f := range fields {
+			fv, ok := fieldByIndex(elem, f.index)
+			if !ok {
+				b.Field(j).AppendNull()
+				continue
+			}
+			if err := appendArrow(b.Field(j), fv); err != nil {
+				return nil, fmt.Errorf("ladybug: row %d field %s: %w", i, f.name, err)
+			}
+		}
+	}
+	return b.NewRecordBatch(), nil
+}
+
+// timestampColumnType returns the Arrow type of a top-level Timestamp field: the unit of its
+// values' precision, which must be the same in every row. Timestamps nested in lists or
+// structs, and columns with no values, are staged with nanoseconds.
+func timestampColumnType(rows reflect.Value, f structField) (arrow.DataType, error) {
+	prec, seen := TimestampNanos, false
+	for i := 0; i < rows.Len(); i++ {
+		elem := rows.Index(i)
+		if elem.Kind() == reflect.Pointer {
+			if elem.IsNil() {
+				continue
+			}
+			elem = elem.Elem()
+		}
+		fv, ok := fieldByIndex(elem, f.index)
+		if fv.Kind() == reflect.Pointer {
+			ok = ok && !fv.IsNil()
+			fv = fv.Elem()
+		}
+		if !ok {
+			continue
+		}
+		p := fv.Interface().(Timestamp).Precision
+		if seen && p != prec {
+			return nil, fmt.Errorf("mixed timestamp precisions %s and %s", prec, p)
+		}
+		prec, seen = p, true
+	}
+	return prec.arrowType(), nil
+}
+
+// arrowType returns the Arrow type timestamps of precision p are staged as.
+func (p TimestampPrecision) arrowType() *arrow.TimestampType {
+	switch p {
+	case TimestampSec:
+		return &arrow.TimestampType{Unit: arrow.Second}
+	case TimestampMillis:
+		return &arrow.TimestampType{Unit: arrow.Millisecond}
+	case TimestampNanos:
+		return &arrow.TimestampType{Unit: arrow.Nanosecond}
+	case TimestampTZ:
+		return &arrow.TimestampType{Unit: arrow.Microsecond, TimeZone: "UTC"}
+	}
+	return &arrow.TimestampType{Unit: arrow.Microsecond}
+}
+
+// arrowTypeOf returns the Arrow type a Go type is staged as.
+func arrowTypeOf(t reflect.Type) (arrow.DataType, error) {
+	switch t {
+	case timeType, timestampType:
+		return &arrow.TimestampType{Unit: arrow.Nanosecond}, nil
+	case dateType:
+		return arrow.FixedWidthTypes.Date32, nil
+	case intervalType, durationType:
+		// Neither staging writer supports Arrow intervals; COPY casts the string to the
+		// column's INTERVAL type.
+		return arrow.BinaryTypes.String, nil
+	case idType, bigIntType, decimalType:
+		// Staging these as structs of their Go fields would load the wrong values.
+		return nil, fmt.Errorf("unsupported type %s (use a string field)", t)
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		return arrowTypeOf(t.Elem())
+	case reflect.Bool:
+		return arrow.FixedWidthTypes.Boolean, nil
+	case reflect.Int, reflect.Int64:
+		return arrow.PrimitiveTypes.Int64, nil
+	case reflect.Int32:
+		return arrow.PrimitiveTypes.Int32, nil
+	case reflect.Int16:
+		return arrow.PrimitiveTypes.Int16, nil
+	case reflect.Int8:
+		return arrow.PrimitiveTypes.Int8, nil
+	case reflect.Uint, reflect.Uint64:
+		return arrow.PrimitiveTypes.Uint64, nil
+	case reflect.Uint32:
+		return arrow.PrimitiveTypes.Uint32, nil
+	case reflect.Uint16:
+		return arrow.PrimitiveTypes.Uint16, nil
+	case reflect.Uint8:
+		return arrow.PrimitiveTypes.Uint8, nil
+	case reflect.Float32:
+		return arrow.PrimitiveTypes.Float32, nil
+	case reflect.Float64:
+		return arrow.PrimitiveTypes.Float64, nil
+	case reflect.String:
+		return arrow.BinaryTypes.String, nil
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 && t.Kind() == reflect.Slice {
+			return arrow.BinaryTypes.Binary, nil
+		}
+		elem, err := arrowTypeOf(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return arrow.ListOf(elem), nil
+	case reflect.Struct:
+		fields := cachedStructFields(t)
+		afs := make([]arrow.Field, len(fields))
+		for i, f := range fields {
+			dt, err := arrowTypeOf(f.typ)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", f.name, err)
+			}
+			afs[i] = arrow.Field{Name: f.name, Type: dt, Nullable: true}
+		}
+		return arrow.StructOf(afs...), nil
+	}
+	return nil, fmt.Errorf("unsupported type %s", t)
+}
+
+// appendArrow appends v to b, which was built from arrowTypeOf(v.Type()).
+func appendArrow(b array.Builder, v reflect.Value) error {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			b.AppendNull()
+			return nil
+		}
+		v = v.Elem()
+	}
+	switch b := b.(type) {
+	case *array.BooleanBuilder:
+		b.Append(v.Bool())
+	case *array.Int64Builder:
+		b.Append(v.Int())
+	case *array.Int32Builder:
+		b.Append(int32(v.Int()))
+	case *array.Int16Builder:
+		b.Append(int16(v.Int()))
+	case *array.Int8Builder:
+		b.Append(int8(v.Int()))
+	case *array.Uint64Builder:
+		b.Append(v.Uint())
+	case *array.Uint32Builder:
+		b.Append(uint32(v.Uint()))
+	case *array.Uint16Builder:
+		b.Append(uint16(v.Uint()))
+	case *array.Uint8Builder:
+		b.Append(uint8(v.Uint()))
+	case *array.Float32Builder:
+		b.Append(float32(v.Float()))
+	case *array.Float64Builder:
+		b.Append(v.Float())
+	case *array.StringBuilder:
+		switch v.Type() {
+		case intervalType:
+			b.Append(v.Interface().(Interval).cypherString())
+		case durationType:
+			b.Append(IntervalFromDuration(time.Duration(v.Int())).cypherString())
+		default:
+			b.Append(v.String())
+		}
+	case *array.BinaryBuilder:
+		if v.IsNil() {
+			b.AppendNull()
+			return nil
+		}
+		b.Append(v.Bytes())
+	case *array.TimestampBuilder:
+		t, ok := v.Interface().(time.Time)
+		if !ok {
+			t = v.Interface().(Timestamp).Time
+		}
+		ts, err := arrow.TimestampFromTime(t, b.Type().(*arrow.TimestampType).Unit)
+		if err != nil {
+			return err
+		}
+		b.Append(ts)
+	case *array.Date32Builder:
+		b.Append(arrow.Date32FromTime(v.Interface().(Date).In(time.UTC)))
+	case *array.ListBuilder:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			b.AppendNull()
+			return nil
+		}
+		b.Append(true)
+		for i := 0; i < v.Len(); i++ {
+			if err := appendArrow(b.ValueBuilder(), v.Index(i)); err != nil {
+				return fmt.Errorf("[%d]: %w", i, err)
+			}
+		}
+	case *array.StructBuilder:
+		b.Append(true)
+		for i, f := range cachedStructFields(v.Type()) {
+			fv, ok := fieldByIndex(v, f.index)
+			if !ok {
+				b.FieldBuilder(i).AppendNull()
+				continue
+			}
+			if err := appendArrow(b.FieldBuilder(i), fv); err != nil {
+				return fmt.Errorf("%s: %w", f.name, err)
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported builder %T", b)
+	}
+	return nil
+}
@@ -0,0 +1,388 @@
+package ladybug
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/vkozio/ladybug-go-zero/internal/lbugc"
+)
+
+// Bind binds v to the parameter $name, converting it by its Go type:
+//
+//   - nil and nil pointers bind NULL; other pointers bind the value they point to
+//   - bool, every signed and unsigned integer width, float32 and float64 bind the matching
+//     Ladybug type (int and uint bind INT64 and UINT64)
+//   - string binds STRING; time.Time binds TIMESTAMP_NS, or TIMESTAMP outside the years
+//     1678-2262 that nanoseconds can hold; Timestamp binds the timestamp type of its
+//     Precision; Date binds DATE; Interval and time.Duration bind INTERVAL
+//   - InternalID binds INTERNAL_ID, so nodes can be matched by id: WHERE id(p) = $id
+//   - *big.Int binds INT128; Decimal binds its STRING form (see Decimal)
+//   - slices and arrays bind LIST (byte slices are not supported)
+//   - maps with string keys and structs bind STRUCT; other maps bind MAP. Empty maps and
+//     structs without exported fields cannot be bound
+//   - driver.Valuer values bind the result of Value()
+//
+// Struct fields are named by their `lbug:"name"` tag, or the field name if untagged;
+// `lbug:"-"` skips a field and unexported fields are ignored. Fields of embedded structs are
+// promoted. Composite values are converted recursively, so a []map[string]any or []T binds
+// as a list of structs suitable for UNWIND.
+func (ps *PreparedStatement) Bind(name string, v any) error {
+	if ps == nil || ps.c == nil {
+		return ErrClosed
+	}
+	val, err := toValue(reflect.ValueOf(v))
+	if err != nil {
+		return fmt.Errorf("ladybug: bind $%s: %w", name, err)
+	}
+	defer val.Destroy()
+	if err := ps.c.BindValue(name, val); err != nil {
+		return wrapErr(err)
+	}
+	ps.noteBound(name)
+	return nil
+}
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+	idType       = reflect.TypeOf(InternalID{})
+	bigIntType   = reflect.TypeOf(big.Int{})
+	intervalType = reflect.TypeOf(Interval{})
+	decimalType  = reflect.TypeOf(Decimal{})
+	valuerType   = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+)
+
+// minNanoTime and maxNanoTime bound the times whose UnixNano fits in an int64.
+var (
+	minNanoTime = time.Unix(0, math.MinInt64)
+	maxNanoTime = time.Unix(0, math.MaxInt64)
+)
+
+// toValue converts a Go value into a newly created C value. The caller destroys it.
+func toValue(rv reflect.Value) (*lbugc.Value, error) {
+	if !rv.IsValid() {
+		return lbugc.NewNull(int(TypeAny))
+	}
+	if rv.Type().Implements(valuerType) {
+		if rv.Kind() == reflect.Pointer && rv.IsNil() {
+			return lbugc.NewNull(int(typeIDOf(rv.Type().Elem())))
+		}
+		dv, err := rv.Interface().(driver.Valuer).Value()
+		if err != nil {
+			return nil, err
+		}
+		return toValue(reflect.ValueOf(dv))
+	}
+	switch rv.Type() {
+	case timeType:
+		t := rv.Interface().(time.Time)
+		if t.Before(minNanoTime) || t.After(maxNanoTime) {
+			return lbugc.NewTimestamp(lbugc.Timestamp{Value: t.UnixMicro(), Unit: lbugc.TimestampMicros})
+		}
+		return lbugc.NewTimestampNS(t.UnixNano())
+	case dateType:
+		d := rv.Interface().(Date)
+		return lbugc.NewDate(d.Year, int(d.Month), d.Day)
+	case timestampType:
+		return lbugc.NewTimestamp(rv.Interface().(Timestamp).driverValue())
+	case durationType:
+		return lbugc.NewInterval(0, 0, time.Duration(rv.Int()).Microseconds())
+	case intervalType:
+		iv := rv.Interface().(Interval)
+		return lbugc.NewInterval(iv.Months, iv.Days, iv.Micros)
+	case idType:
+		id := rv.Interface().(InternalID)
+		return lbugc.NewInternalID(id.Table, id.Offset)
+	case bigIntType:
+		var i *big.Int
+		if rv.CanAddr() {
+			i = rv.Addr().Interface().(*big.Int)
+		} else {
+			v := rv.Interface().(big.Int)
+			i = &v
+		}
+		if !fitsInt128(i) {
+			return nil, fmt.Errorf("%s overflows INT128", i)
+		}
+		return lbugc.NewInt128(i)
+	}
+
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if rv.IsNil() {
+			elem := rv.Type()
+			if rv.Kind() == reflect.Pointer {
+				elem = elem.Elem()
+			}
+			return lbugc.NewNull(int(typeIDOf(elem)))
+		}
+		return toValue(rv.Elem())
+	case reflect.Bool:
+		return lbugc.NewBool(rv.Bool())
+	case reflect.Int, reflect.Int64:
+		return lbugc.NewInt64(rv.Int())
+	case reflect.Int32:
+		return lbugc.NewInt32(int32(rv.Int()))
+	case reflect.Int16:
+		return lbugc.NewInt16(int16(rv.Int()))
+	case reflect.Int8:
+		return lbugc.NewInt8(int8(rv.Int()))
+	case reflect.Uint, reflect.Uint64, reflect.Uintptr:
+		return lbugc.NewUInt64(rv.Uint())
+	case reflect.Uint32:
+		return lbugc.NewUInt32(uint32(rv.Uint()))
+	case reflect.Uint16:
+		return lbugc.NewUInt16(uint16(rv.Uint()))
+	case reflect.Uint8:
+		return lbugc.NewUInt8(uint8(rv.Uint()))
+	case reflect.Float32:
+		return lbugc.NewFloat(float32(rv.Float()))
+	case reflect.Float64:
+		return lbugc.NewDouble(rv.Float())
+	case reflect.String:
+		return lbugc.NewString(rv.String())
+	case reflect.Slice, reflect.Array:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return nil, fmt.Errorf("byte slices are not supported")
+		}
+		if rv.Kind() == reflect.Slice && rv.IsNil() {
+			return lbugc.NewNull(int(TypeAny))
+		}
+		return listValue(rv)
+	case reflect.Map:
+		if rv.IsNil() {
+			return lbugc.NewNull(int(TypeAny))
+		}
+		if rv.Type().Key().Kind() == reflect.String {
+			return stringMapValue(rv)
+		}
+		return mapValue(rv)
+	case reflect.Struct:
+		return structValue(rv)
+	}
+	return nil, fmt.Errorf("unsupported type %s", rv.Type())
+}
+
+// typeIDOf returns the Ladybug type a Go type binds as, for typed NULLs and empty lists.
+// Composite and unknown types map to ANY.
+func typeIDOf(t reflect.Type) TypeID {
+	switch t {
+	case timeType:
+		return TypeTimestampNS
+	case dateType:
+		return TypeDate
+	case timestampType:
+		return TypeTimestamp
+	case durationType, intervalType:
+		return TypeInterval
+	case idType:
+		return TypeInternalID
+	case bigIntType:
+		return TypeInt128
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return TypeBool
+	case reflect.Int, reflect.Int64:
+		return TypeInt64
+	case reflect.Int32:
+		return TypeInt32
+	case reflect.Int16:
+		return TypeInt16
+	case reflect.Int8:
+		return TypeInt8
+	case reflect.Uint, reflect.Uint64, reflect.Uintptr:
+		return TypeUInt64
+	case reflect.Uint32:
+		return TypeUInt32
+	case reflect.Uint16:
+		return TypeUInt16
+	case reflect.Uint8:
+		return TypeUInt8
+	case reflect.Float32:
+		return TypeFloat
+	case reflect.Float64:
+		return TypeDouble
+	case reflect.String:
+		return TypeString
+	}
+	return TypeAny
+}
+
+// destroyValues destroys every non-nil value in vs.
+func destroyValues(vs []*lbugc.Value) {
+	for _, v := range vs {
+		v.Destroy()
+	}
+}
+
+func listValue(rv reflect.Value) (*lbugc.Value, error) {
+	n := rv.Len()
+	if n == 0 {
+		return lbugc.NewEmptyList(int(typeIDOf(rv.Type().Elem())))
+	}
+	elems := make([]*lbugc.Value, 0, n)
+	defer func() { destroyValues(elems) }()
+	for i := 0; i < n; i++ {
+		e, err := toValue(rv.Index(i))
+		if err != nil {
+			return nil, fmt.Errorf("[%d]: %w", i, err)
+		}
+		elems = append(elems, e)
+	}
+	return lbugc.NewList(elems)
+}
+
+// errEmptyComposite is returned for empty maps and field-less structs: a STRUCT needs at
+// least one field, and an empty MAP has no key and value types to infer.
+var errEmptyComposite = errors.New("empty map is not supported")
+
+func stringMapValue(rv reflect.Value) (*lbugc.Value, error) {
+	if rv.Len() == 0 {
+		return nil, errEmptyComposite
+	}
+	keys := make([]string, 0, rv.Len())
+	for _, k := range rv.MapKeys() {
+		keys = append(keys, k.String())
+	}
+	sort.Strings(keys)
+	vals := make([]*lbugc.Value, 0, len(keys))
+	defer func() { destroyValues(vals) }()
+	for _, k := range keys {
+		v, err := toValue(rv.MapIndex(reflect.ValueOf(k).Convert(rv.Type().Key())))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", k, err)
+		}
+		vals = append(vals, v)
+	}
+	return lbugc.NewStruct(keys, vals)
+}
+
+func mapValue(rv reflect.Value) (*lbugc.Value, error) {
+	if rv.Len() == 0 {
+		return nil, errEmptyComposite
+	}
+	keys := make([]*lbugc.Value, 0, rv.Len())
+	vals := make([]*lbugc.Value, 0, rv.Len())
+	defer func() {
+		destroyValues(keys)
+		destroyValues(vals)
+	}()
+	iter := rv.MapRange()
+	for iter.Next() {
+		k, err := toValue(iter.Key())
+		if err != nil {
+			return nil, fmt.Errorf("map key %v: %w", iter.Key(), err)
+		}
+		keys = append(keys, k)
+		v, err := toValue(iter.Value())
+		if err != nil {
+			return nil, fmt.Errorf("map value %v: %w", iter.Key(), err)
+		}
+		vals = append(vals, v)
+	}
+	return lbugc.NewMap(keys, vals)
+}
+
+func structValue(rv reflect.Value) (*lbugc.Value, error) {
+	fields := cachedStructFields(rv.Type())
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("struct %s has no exported fields", rv.Type())
+	}
+	names := make([]string, 0, len(fields))
+	vals := make([]*lbugc.Value, 0, len(fields))
+	defer func() { destroyValues(vals) }()
+	for _, f := range fields {
+		fv, ok := fieldByIndex(rv, f.index)
+		var v *lbugc.Value
+		var err error
+		if ok {
+			v, err = toValue(fv)
+		} else {
+			v, err = lbugc.NewNull(int(typeIDOf(f.typ)))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", f.name, err)
+		}
+		names = append(names, f.name)
+		vals = append(vals, v)
+	}
+	return lbugc.NewStruct(names, vals)
+}
+
+// structField is an exported struct field (possibly promoted from an embedded struct) and
+// the column/property name it maps to.
+type structField struct {
+	name  string
+	index []int
+	typ   reflect.Type
+}
+
+// structFields lists the fields of struct type t using `lbug:"name"` tags, falling back to
+// the field name. Untagged embedded structs are flattened; `lbug:"-"` skips a field. When a
+// promoted field collides with a shallower one, the shallower field wins.
+func structFields(t reflect.Type) []structField {
+	var out []structField
+	seen := map[string]int{}
+	var walk func(t reflect.Type, index []int)
+	walk = func(t reflect.Type, index []int) {
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			tag := f.Tag.Get("lbug")
+			if tag == "-" {
+				continue
+			}
+			name, _, _ := strings.Cut(tag, ",")
+			idx := append(append([]int(nil), index...), i)
+			ft := f.Type
+			if f.Anonymous && name == "" {
+				if ft.Kind() == reflect.Pointer {
+					ft = ft.Elem()
+				}
+				if ft.Kind() == reflect.Struct && ft != timeType {
+					walk(ft, idx)
+					continue
+				}
+			}
+			if !f.IsExported() {
+				continue
+			}
+			if name == "" {
+				name = f.Name
+			}
+			if j, dup := seen[name]; dup {
+				if len(out[j].index) <= len(idx) {
+					continue
+				}
+				out[j] = structField{name: name, index: idx, typ: f.Type}
+				continue
+			}
+			seen[name] = len(out)
+			out = append(out, structField{name: name, index: idx, typ: f.Type})
+		}
+	}
+	walk(t, nil)
+	return out
+}
+
+// fieldByIndex is reflect.Value.FieldByIndex that reports false instead of panicking when
+// the path crosses a nil embedded pointer.
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
@@ -0,0 +1,272 @@
+package ladybug
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/csv"
+	"github.com/apache/arrow-go/v18/parquet"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+)
+
+// BulkFormat is the file format BulkLoad stages data in.
+type BulkFormat int
+
+const (
+	// BulkParquet stages data as Parquet. It supports nested columns and is the default.
+	BulkParquet BulkFormat = iota
+	// BulkCSV stages data as CSV with a header row. Only scalar columns and lists of them are
+	// supported; BulkLoad rejects records with struct or map columns.
+	BulkCSV
+)
+
+// BulkLoadOptions configures BulkLoad. The zero value is valid.
+type BulkLoadOptions struct {
+	Format BulkFormat
+	// TempDir is the directory for the staging file ("" = os.TempDir()). It must be readable
+	// by the database process.
+	TempDir string
+	// From and To name the node tables of a REL table with several FROM/TO pairs.
+	From, To string
+	// IgnoreErrors skips rows that fail to load (bad values, duplicate primary keys) instead
+	// of aborting the COPY. The skipped rows are reported in BulkLoadResult.Errors.
+	IgnoreErrors bool
+}
+
+// BulkLoadResult reports the outcome of BulkLoad.
+type BulkLoadResult struct {
+	// Rows is the number of rows copied into the table.
+	Rows int64
+	// Staged is the number of rows written to the staging file.
+	Staged int64
+	// Errors lists the rows skipped with IgnoreErrors.
+	Errors []BulkLoadError
+}
+
+// BulkLoadError describes one row skipped by a COPY with IgnoreErrors.
+type BulkLoadError struct {
+	Message string
+	// Line is the line (CSV) or row number of the skipped record in the staging file, if known.
+	Line int64
+	// Record is the skipped record as reported by the library, if known.
+	Record string
+}
+
+// BulkLoad copies every record of rr into table by staging them in a temporary Parquet or
+// CSV file and running COPY ... FROM on it. Columns must be in the table's property order;
+// for REL tables the first two columns are the primary keys of the source and destination
+// nodes. The staging file is removed when BulkLoad returns.
+func (db *Database) BulkLoad(ctx context.Context, table string, rr array.RecordReader, opts *BulkLoadOptions) (BulkLoadResult, error) {
+	var out BulkLoadResult
+	if db == nil || db.c == nil {
+		return out, ErrClosed
+	}
+	if opts == nil {
+		opts = &BulkLoadOptions{}
+	}
+	ext := ".parquet"
+	if opts.Format == BulkCSV {
+		ext = ".csv"
+	}
+	f, err := os.CreateTemp(opts.TempDir, "ladybug-bulk-*"+ext)
+	if err != nil {
+		return out, fmt.Errorf("ladybug: bulk load: %w", err)
+	}
+	path := f.Name()
+	defer os.Remove(path)
+	out.Staged, err = stageRecords(f, rr, opts.Format)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return out, fmt.Errorf("ladybug: bulk load: stage %s: %w", table, err)
+	}
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return out, err
+	}
+	defer conn.Close()
+	res, err := conn.Query(ctx, copyStatement(table, path, opts))
+	if err != nil {
+		return out, err
+	}
+	out.Rows = copiedRows(res, out.Staged)
+	res.Close()
+	if opts.IgnoreErrors {
+		if out.Errors, err = copyWarnings(ctx, conn); err != nil {
+			return out, err
+		}
+		if out.Rows == out.Staged {
+			out.Rows -= int64(len(out.Errors))
+		}
+	}
+	return out, nil
+}
+
+// BulkLoadSlice is BulkLoad for a slice of structs, converted to Arrow with the field naming
+// rules of PreparedStatement.Bind. Field order must match the table's property order.
+// Supported field types are booleans, integers, floats, strings, []byte, time.Time, Date,
+// Timestamp, Interval, time.Duration, and slices, arrays, structs and pointers (nil = NULL) of
+// those. InternalID, Decimal and big.Int fields are rejected; stage them as strings.
+// time.Time is staged with nanoseconds; a Timestamp field with the precision of its values,
+// which must be the same in every row. Interval and time.Duration fields are staged as
+// strings that COPY casts to the column's INTERVAL type.
+func BulkLoadSlice[T any](ctx context.Context, db *Database, table string, rows []T, opts *BulkLoadOptions) (BulkLoadResult, error) {
+	rec, err := recordFromSlice(rows)
+	if err != nil {
+		return BulkLoadResult{}, err
+	}
+	defer rec.Release()
+	rr, err := array.NewRecordReader(rec.Schema(), []arrow.RecordBatch{rec})
+	if err != nil {
+		return BulkLoadResult{}, err
+	}
+	defer rr.Release()
+	return db.BulkLoad(ctx, table, rr, opts)
+}
+
+func stageRecords(f *os.File, rr array.RecordReader, format BulkFormat) (int64, error) {
+	var rows int64
+	switch format {
+	case BulkParquet:
+		w, err := pqarrow.NewFileWriter(rr.Schema(), f, parquet.NewWriterProperties(), pqarrow.DefaultWriterProps())
+		if err != nil {
+			return 0, err
+		}
+		for rr.Next() {
+			rec := rr.RecordBatch()
+			if err := w.Write(rec); err != nil {
+				w.Close()
+				return rows, err
+			}
+			rows += rec.NumRows()
+		}
+		if err := rr.Err(); err != nil {
+			w.Close()
+			return rows, err
+		}
+		return rows, w.Close()
+	case BulkCSV:
+		for _, f := range rr.Schema().Fields() {
+			if !csvWritable(f.Type) {
+				return 0, fmt.Errorf("field %s: type %s is not supported in CSV; use BulkParquet", f.Name, f.Type)
+			}
+		}
+		w := csv.NewWriter(f, rr.Schema(), csv.WithHeader(true), csv.WithNullWriter(""))
+		for rr.Next() {
+			rec := rr.RecordBatch()
+			if err := w.Write(rec); err != nil {
+				return rows, err
+			}
+			rows += rec.NumRows()
+		}
+		if err := rr.Err(); err != nil {
+			return rows, err
+		}
+		w.Flush()
+		return rows, w.Error()
+	}
+	return 0, fmt.Errorf("unknown format %d", format)
+}
+
+// csvWritable reports whether the CSV writer can encode columns of type dt; it panics on
+// the others.
+func csvWritable(dt arrow.DataType) bool {
+	switch dt := dt.(type) {
+	case *arrow.BooleanType, *arrow.Int8Type, *arrow.Int16Type, *arrow.Int32Type, *arrow.Int64Type,
+		*arrow.Uint8Type, *arrow.Uint16Type, *arrow.Uint32Type, *arrow.Uint64Type,
+		*arrow.Float16Type, *arrow.Float32Type, *arrow.Float64Type,
+		*arrow.StringType, *arrow.LargeStringType, *arrow.BinaryType, *arrow.LargeBinaryType,
+		*arrow.FixedSizeBinaryType, *arrow.Date32Type, *arrow.Date64Type, *arrow.TimestampType,
+		*arrow.Decimal128Type, *arrow.Decimal256Type, *arrow.NullType, arrow.ExtensionType:
+		return true
+	case *arrow.MapType:
+		return false
+	case arrow.ListLikeType:
+		return csvWritable(dt.Elem())
+	}
+	return false
+}
+
+// copyStatement builds the COPY ... FROM statement for a staging file.
+func copyStatement(table, path string, opts *BulkLoadOptions) string {
+	var params []string
+	if opts.Format == BulkCSV {
+		params = append(params, "HEADER=true")
+	}
+	if opts.IgnoreErrors {
+		params = append(params, "IGNORE_ERRORS=true")
+	}
+	if opts.From != "" {
+		params = append(params, "from="+quoteString(opts.From))
+	}
+	if opts.To != "" {
+		params = append(params, "to="+quoteString(opts.To))
+	}
+	stmt := "COPY " + quoteIdent(table) + " FROM " + quoteString(path)
+	if len(params) > 0 {
+		stmt += " (" + strings.Join(params, ", ") + ")"
+	}
+	return stmt
+}
+
+// quoteIdent quotes a Cypher identifier with backticks.
+func quoteIdent(s string) string {
+	return "`" + strings.ReplaceAll(s, "`", "``") + "`"
+}
+
+// quoteString quotes a Cypher string literal.
+func quoteString(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s) + "'"
+}
+
+var copiedRe = regexp.MustCompile(`(\d+) tuples? (?:has|have) been copied`)
+
+// copiedRows reads the row count from COPY's status message, falling back to staged.
+func copiedRows(res *Result, staged int64) int64 {
+	row, ok := res.Next()
+	if !ok {
+		return staged
+	}
+	msg, err := row.String(0)
+	if err != nil {
+		return staged
+	}
+	m := copiedRe.FindStringSubmatch(msg)
+	if m == nil {
+		return staged
+	}
+	n, err := strconv.ParseInt(m[1], 10, 64)
+	if err != nil {
+		return staged
+	}
+	return n
+}
+
+// copyWarnings returns and clears the warnings recorded by the last COPY on conn.
+func copyWarnings(ctx context.Context, conn *Connection) ([]BulkLoadError, error) {
+	type warning struct {
+		Message string `lbug:"message"`
+		Line    int64  `lbug:"line_number"`
+		Record  string `lbug:"skipped_line_or_record"`
+	}
+	ws, err := QueryAll[warning](ctx, conn, "CALL show_warnings() RETURN message, line_number, skipped_line_or_record", nil)
+	if err != nil {
+		return nil, err
+	}
+	if res, err := conn.Query(ctx, "CALL clear_warnings()"); err == nil {
+		res.Close()
+	}
+	out := make([]BulkLoadError, len(ws))
+	for i, w := range ws {
+		out[i] = BulkLoadError(w)
+	}
+	return out, nil
+}
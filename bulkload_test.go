@@ -0,0 +1,320 @@
+package ladybug
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+)
+
+func TestRecordFromSlice(t *testing.T) {
+	type addr struct {
+		City string `lbug:"city"`
+	}
+	type person struct {
+		Name  string `lbug:"name"`
+		Age   *int64 `lbug:"age"`
+		Tags  []string
+		Blob  []byte
+		Since time.Time
+		Addr  addr
+		Skip  int `lbug:"-"`
+	}
+	age := int64(30)
+	since := time.Date(2020, 1, 2, 3, 4, 5, 6000, time.UTC)
+	rec, err := recordFromSlice([]person{
+		{Name: "ann", Age: &age, Tags: []string{"a", "b"}, Blob: []byte{1}, Since: since, Addr: addr{"Oslo"}},
+		{Name: "bob"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rec.Release()
+	var names []string
+	for _, f := range rec.Schema().Fields() {
+		names = append(names, f.Name)
+	}
+	if got, want := len(names), 6; got != want {
+		t.Fatalf("columns = %v, want %d", names, want)
+	}
+	if names[0] != "name" || names[1] != "age" || names[5] != "Addr" {
+		t.Errorf("columns = %v", names)
+	}
+	if rec.NumRows() != 2 {
+		t.Fatalf("rows = %d, want 2", rec.NumRows())
+	}
+	ages := rec.Column(1).(*array.Int64)
+	if ages.Value(0) != 30 || !ages.IsNull(1) {
+		t.Errorf("age = %v", ages)
+	}
+	if tags := rec.Column(2).(*array.List); tags.IsNull(0) || !tags.IsNull(1) {
+		t.Errorf("tags = %v", tags)
+	}
+	if ts := rec.Column(4).(*array.Timestamp); ts.Value(0) != arrow.Timestamp(since.UnixNano()) {
+		t.Errorf("since = %v", ts.Value(0))
+	}
+	city := rec.Column(5).(*array.Struct).Field(0).(*array.String)
+	if city.Value(0) != "Oslo" {
+		t.Errorf("city = %q", city.Value(0))
+	}
+
+	if _, err := recordFromSlice([]int{1}); err == nil {
+		t.Error("expected error for non-struct rows")
+	}
+	if _, err := recordFromSlice([]struct{ M map[string]int }{{}}); err == nil {
+		t.Error("expected error for map field")
+	}
+
+	rec, err = recordFromSlice([]struct {
+		I Interval
+		D *time.Duration
+	}{{I: Interval{Months: 1, Days: 2, Micros: 3}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rec.Release()
+	if got := rec.Column(0).(*array.String).Value(0); got != "1 months 2 days 3 microseconds" {
+		t.Errorf("interval = %q", got)
+	}
+	if !rec.Column(1).IsNull(0) || !arrow.TypeEqual(rec.Schema().Field(1).Type, arrow.BinaryTypes.String) {
+		t.Errorf("duration column = %s", rec.Column(1))
+	}
+	for _, format := range []BulkFormat{BulkParquet, BulkCSV} {
+		rr, err := array.NewRecordReader(rec.Schema(), []arrow.RecordBatch{rec})
+		if err != nil {
+			t.Fatal(err)
+		}
+		f, err := os.CreateTemp(t.TempDir(), "stage")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := stageRecords(f, rr, format); err != nil {
+			t.Errorf("stage intervals as format %d: %v", format, err)
+		}
+		f.Close()
+		rr.Release()
+	}
+	type stamped struct {
+		Ms *Timestamp
+		TZ Timestamp
+	}
+	rec, err = recordFromSlice([]stamped{
+		{Ms: &Timestamp{Time: since, Precision: TimestampMillis}, TZ: Timestamp{Time: since, Precision: TimestampTZ}},
+		{TZ: Timestamp{Time: since, Precision: TimestampTZ}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rec.Release()
+	for i, want := range []*arrow.TimestampType{
+		{Unit: arrow.Millisecond},
+		{Unit: arrow.Microsecond, TimeZone: "UTC"},
+	} {
+		if got := rec.Schema().Field(i).Type; !arrow.TypeEqual(got, want) {
+			t.Errorf("column %d type = %s, want %s", i, got, want)
+		}
+	}
+	if got := rec.Column(0).(*array.Timestamp).Value(0); got != arrow.Timestamp(since.UnixMilli()) {
+		t.Errorf("TIMESTAMP_MS value = %d", got)
+	}
+	if _, err := recordFromSlice([]stamped{
+		{TZ: Timestamp{Time: since, Precision: TimestampSec}},
+		{TZ: Timestamp{Time: since, Precision: TimestampNanos}},
+	}); err == nil {
+		t.Error("expected error for mixed timestamp precisions")
+	}
+
+	for _, typ := range []reflect.Type{idType, decimalType, reflect.TypeFor[*big.Int]()} {
+		if _, err := arrowTypeOf(typ); err == nil {
+			t.Errorf("arrowTypeOf(%s) succeeded", typ)
+		}
+	}
+}
+
+func TestCopyStatement(t *testing.T) {
+	got := copyStatement("Kn`ows", "/tmp/it's.csv", &BulkLoadOptions{Format: BulkCSV, IgnoreErrors: true, From: "A", To: "B"})
+	want := "COPY `Kn``ows` FROM '/tmp/it\\'s.csv' (HEADER=true, IGNORE_ERRORS=true, from='A', to='B')"
+	if got != want {
+		t.Errorf("copyStatement = %s, want %s", got, want)
+	}
+	if got := copyStatement("P", "/x.parquet", &BulkLoadOptions{}); got != "COPY `P` FROM '/x.parquet'" {
+		t.Errorf("copyStatement = %s", got)
+	}
+}
+
+func TestBulkLoad(t *testing.T) {
+	ver, _ := Version()
+	if ver == "" {
+		t.Skip("Ladybug libs not available; skipping")
+	}
+	dir := t.TempDir()
+	ctx := context.Background()
+	db, err := Open(ctx, filepath.Join(dir, "testdb"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	for _, q := range []string{
+		"CREATE NODE TABLE Person(name STRING PRIMARY KEY, age INT64)",
+		"CREATE REL TABLE Knows(FROM Person TO Person, since INT64)",
+	} {
+		res, err := conn.Query(ctx, q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Close()
+	}
+
+	type person struct {
+		Name string `lbug:"name"`
+		Age  int64  `lbug:"age"`
+	}
+	tmp := t.TempDir()
+	opts := &BulkLoadOptions{TempDir: tmp}
+	out, err := BulkLoadSlice(ctx, db, "Person", []person{{"ann", 30}, {"bob", 40}}, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.Rows != 2 || out.Staged != 2 {
+		t.Errorf("person load = %+v, want 2 rows", out)
+	}
+
+	type knows struct {
+		From  string
+		To    string
+		Since int64
+	}
+	out, err = BulkLoadSlice(ctx, db, "Knows", []knows{{"ann", "bob", 2020}}, &BulkLoadOptions{TempDir: tmp, Format: BulkCSV})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.Rows != 1 {
+		t.Errorf("knows load = %+v, want 1 row", out)
+	}
+
+	out, err = BulkLoadSlice(ctx, db, "Person", []person{{"ann", 1}, {"cat", 50}}, &BulkLoadOptions{TempDir: tmp, IgnoreErrors: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.Rows != 1 || len(out.Errors) != 1 {
+		t.Errorf("duplicate load = %+v, want 1 row and 1 error", out)
+	}
+
+	if _, err := BulkLoadSlice(ctx, db, "Missing", []person{{"dan", 1}}, opts); err == nil {
+		t.Error("expected error for missing table")
+	}
+	if ents, _ := os.ReadDir(tmp); len(ents) != 0 {
+		t.Errorf("staging files left behind: %v", ents)
+	}
+
+	n, err := QueryOne[int64](ctx, conn, "MATCH (p:Person) RETURN count(p)", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 3 {
+		t.Errorf("person count = %d, want 3", n)
+	}
+}
+
+func TestBulkLoadInterval(t *testing.T) {
+	ver, _ := Version()
+	if ver == "" {
+		t.Skip("Ladybug libs not available; skipping")
+	}
+	ctx := context.Background()
+	db, err := Open(ctx, filepath.Join(t.TempDir(), "testdb"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	res, err := conn.Query(ctx, "CREATE NODE TABLE Job(name STRING PRIMARY KEY, every INTERVAL, took INTERVAL)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Close()
+
+	type job struct {
+		Name  string        `lbug:"name"`
+		Every Interval      `lbug:"every"`
+		Took  time.Duration `lbug:"took"`
+	}
+	every := Interval{Months: -1, Days: 2, Micros: 3_500_000}
+	for i, format := range []BulkFormat{BulkParquet, BulkCSV} {
+		name := fmt.Sprint("job", i)
+		out, err := BulkLoadSlice(ctx, db, "Job", []job{{name, every, 90 * time.Second}}, &BulkLoadOptions{Format: format})
+		if err != nil {
+			t.Fatalf("format %d: %v", format, err)
+		}
+		if out.Rows != 1 {
+			t.Errorf("format %d: load = %+v, want 1 row", format, out)
+		}
+		got, err := QueryOne[job](ctx, conn, "MATCH (j:Job {name: $n}) RETURN j.name AS name, j.every AS every, j.took AS took", map[string]any{"n": name})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Every != every || got.Took != 90*time.Second {
+			t.Errorf("format %d: loaded %+v", format, got)
+		}
+	}
+}
+
+func TestStageRecordsCSV(t *testing.T) {
+	type addr struct{ City string }
+	type row struct {
+		Name string
+		Addr addr
+	}
+	rec, err := recordFromSlice([]row{{"ann", addr{"Oslo"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rec.Release()
+	mapType := arrow.MapOf(arrow.BinaryTypes.String, arrow.PrimitiveTypes.Int64)
+	for _, tc := range []struct {
+		typ  arrow.DataType
+		want bool
+	}{
+		{arrow.PrimitiveTypes.Int64, true},
+		{arrow.ListOf(arrow.BinaryTypes.String), true},
+		{rec.Schema().Field(1).Type, false},
+		{mapType, false},
+		{arrow.ListOf(mapType), false},
+		{arrow.FixedWidthTypes.MonthDayNanoInterval, false},
+	} {
+		if got := csvWritable(tc.typ); got != tc.want {
+			t.Errorf("csvWritable(%s) = %v, want %v", tc.typ, got, tc.want)
+		}
+	}
+
+	rr, err := array.NewRecordReader(rec.Schema(), []arrow.RecordBatch{rec})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rr.Release()
+	f, err := os.CreateTemp(t.TempDir(), "stage")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, err := stageRecords(f, rr, BulkCSV); err == nil || !strings.Contains(err.Error(), "field Addr") {
+		t.Errorf("stage struct column as CSV: err = %v, want error naming Addr", err)
+	}
+}
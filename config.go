@@ -1,6 +1,14 @@
 package ladybug
 
-import "context"
+import (
+	"context"
+	"fmt"
+	"math/bits"
+	"runtime"
+	"time"
+
+	"github.com/vkozio/ladybug-go-zero/internal/lbugc"
+)
 
 // Config holds options for opening a database.
 // Zero value uses Ladybug defaults (see lbug_default_system_config).
@@ -13,7 +21,73 @@ type Config struct {
 	BufferPoolSize uint64
 	// MaxNumThreads is the max threads for query execution (0 = default).
 	MaxNumThreads uint64
+	// DisableCompression turns off on-disk compression (enable_compression = false).
+	DisableCompression bool
+	// MaxDBSize is the maximum database size in bytes (0 = default). Must be a power of two.
+	MaxDBSize uint64
+	// DisableAutoCheckpoint turns off automatic checkpointing when the WAL grows past CheckpointThreshold.
+	DisableAutoCheckpoint bool
+	// CheckpointThreshold is the WAL size in bytes that triggers an automatic checkpoint (0 = default).
+	CheckpointThreshold uint64
+	// ThreadQoS is the worker thread quality of service (0 = default). Apple platforms only.
+	ThreadQoS uint32
+	// StatementCacheSize is the number of prepared statements each Connection keeps in an LRU
+	// cache keyed by Cypher text (0 = no cache). The cache is used by QueryParams, QueryNamed
+	// and database/sql queries with arguments, and is cleared when a DDL statement runs on the
+	// connection. A cached statement is only reused by calls binding the same parameters.
+	StatementCacheSize int
+	// Location is the time zone timestamps are rendered in (nil = UTC). WithLocation
+	// overrides it for one query. It does not change stored values or DATE columns.
+	Location *time.Location
 	// OnQueryFinished, if non-nil, is called after each Query or Execute.
-	// Summary may be zero-valued if underlying support is unavailable.
+	// Summary may be zero-valued if underlying support is unavailable. It runs after the
+	// call has released the connection, so it may use the same Connection.
 	OnQueryFinished func(ctx context.Context, cypher string, summary QuerySummary, err error)
 }
+
+// systemConfig validates cfg and merges it over the library defaults.
+func (cfg *Config) systemConfig() (lbugc.SystemConfig, error) {
+	sc := lbugc.DefaultSystemConfig()
+	if cfg == nil {
+		return sc, nil
+	}
+	if cfg.MaxDBSize != 0 && bits.OnesCount64(cfg.MaxDBSize) != 1 {
+		return sc, fmt.Errorf("ladybug: MaxDBSize must be a power of two (got %d)", cfg.MaxDBSize)
+	}
+	if cfg.BufferPoolSize != 0 && cfg.MaxDBSize != 0 && cfg.BufferPoolSize > cfg.MaxDBSize {
+		return sc, fmt.Errorf("ladybug: BufferPoolSize (%d) exceeds MaxDBSize (%d)", cfg.BufferPoolSize, cfg.MaxDBSize)
+	}
+	if cfg.DisableAutoCheckpoint && cfg.CheckpointThreshold != 0 {
+		return sc, fmt.Errorf("ladybug: CheckpointThreshold is set but DisableAutoCheckpoint is true")
+	}
+	if cfg.StatementCacheSize < 0 {
+		return sc, fmt.Errorf("ladybug: StatementCacheSize must not be negative (got %d)", cfg.StatementCacheSize)
+	}
+	if cfg.ThreadQoS != 0 && runtime.GOOS != "darwin" {
+		return sc, fmt.Errorf("ladybug: ThreadQoS is only supported on darwin")
+	}
+
+	if cfg.BufferPoolSize != 0 {
+		sc.BufferPoolSize = cfg.BufferPoolSize
+	}
+	if cfg.MaxNumThreads != 0 {
+		sc.MaxNumThreads = cfg.MaxNumThreads
+	}
+	if cfg.MaxDBSize != 0 {
+		sc.MaxDBSize = cfg.MaxDBSize
+	}
+	if cfg.CheckpointThreshold != 0 {
+		sc.CheckpointThreshold = cfg.CheckpointThreshold
+	}
+	if cfg.ThreadQoS != 0 {
+		sc.ThreadQoS = cfg.ThreadQoS
+	}
+	sc.ReadOnly = cfg.ReadOnly
+	if cfg.DisableCompression {
+		sc.EnableCompression = false
+	}
+	if cfg.DisableAutoCheckpoint {
+		sc.AutoCheckpoint = false
+	}
+	return sc, nil
+}
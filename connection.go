@@ -3,6 +3,9 @@ package ladybug
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
+	"sync/atomic"
 	"time"
 
 	"github.com/vkozio/ladybug-go-zero/internal/lbugc"
@@ -10,31 +13,59 @@ import (
 
 // Connection is a connection to a Ladybug database. Call Close when done.
 // Per lbug.h, the underlying C connection is thread-safe. However, Result iteration is not
-// safe for concurrent use (consume a Result from one goroutine at a time).
+// safe for concurrent use (consume a Result from one goroutine at a time), and calls that
+// change settings for their duration (see QueryOption) are serialized with all other calls.
 type Connection struct {
 	c   *lbugc.Connection
 	cfg *Config
+	// mu is held exclusively by calls that change connection settings and by setters, and
+	// shared by all other calls (see applyOptions).
+	mu connLock
+	// stmts caches prepared statements for QueryParams and QueryNamed; nil when disabled.
+	stmts *stmtCache
+	// tx is the open transaction started by BeginTx, if any.
+	tx atomic.Pointer[Tx]
+	// pool and base are set on connections handed out by Pool.Acquire; base is the pooled
+	// connection this one wraps.
+	pool *Pool
+	base *Connection
+	// timeout is the query timeout set with SetQueryTimeout.
+	timeout time.Duration
+	// threads is the thread limit a pooled connection is reset to; 0 if unknown.
+	threads uint64
 }
 
-// Close closes the connection.
+// Close closes the connection. A connection from Pool.Acquire is reset and returned to
+// the pool instead, and must not be used afterwards.
 func (c *Connection) Close() error {
 	if c == nil || c.c == nil {
 		return nil
 	}
+	if c.pool != nil {
+		c.reset()
+		c.c = nil
+		c.pool.release(c.base)
+		return nil
+	}
+	if c.stmts != nil {
+		c.stmts.close()
+	}
 	c.c.Close()
 	c.c = nil
 	return nil
 }
 
-// Query runs a Cypher query and returns a Result. Caller must call Result.Close.
-func (c *Connection) Query(ctx context.Context, cypher string) (*Result, error) {
+// Query runs a Cypher query and returns a Result. opts apply to this call only.
+// Caller must call Result.Close.
+func (c *Connection) Query(ctx context.Context, cypher string, opts ...QueryOption) (*Result, error) {
 	if c == nil || c.c == nil {
 		return nil, ErrInvalidConn
 	}
 	if ctx != nil && ctx.Err() != nil {
 		return nil, ctx.Err()
 	}
-	if err := c.setTimeoutFromContext(ctx); err != nil {
+	ctx, o, restore, err := c.applyOptions(ctx, opts)
+	if err != nil {
 		return nil, err
 	}
 
@@ -48,18 +79,30 @@ func (c *Connection) Query(ctx context.Context, cypher string) (*Result, error)
 			}
 		}()
 	}
-	defer close(done)
+	// finish stops the interrupt watcher and releases the connection, so that hooks may
+	// use it.
+	finished := false
+	finish := func() {
+		if !finished {
+			finished = true
+			close(done)
+			restore()
+		}
+	}
+	defer finish()
 
 	res, err := c.c.Query(cypher)
 	if err != nil {
-		wrapped := fmt.Errorf("ladybug: %w", err)
+		wrapped := wrapCtxErr(ctx, err)
+		finish()
 		invokeQueryHook(c.cfg, ctx, cypher, QuerySummary{}, wrapped)
 		return nil, wrapped
 	}
-	r := &Result{c: res}
+	r := &Result{c: res, chunkSize: o.chunkSize, loc: o.location}
 	if ctx != nil && ctx.Err() != nil {
 		r.Close()
 		errCtx := ctx.Err()
+		finish()
 		invokeQueryHook(c.cfg, ctx, cypher, QuerySummary{}, errCtx)
 		return nil, errCtx
 	}
@@ -68,6 +111,8 @@ func (c *Connection) Query(ctx context.Context, cypher string) (*Result, error)
 	if s, err := r.Summary(); err == nil && s != nil {
 		summary = *s
 	}
+	c.invalidateStmtCache(cypher)
+	finish()
 	invokeQueryHook(c.cfg, ctx, cypher, summary, nil)
 	return r, nil
 }
@@ -80,9 +125,11 @@ func (c *Connection) Prepare(ctx context.Context, cypher string) (*PreparedState
 	if ctx != nil && ctx.Err() != nil {
 		return nil, ctx.Err()
 	}
-	if err := c.setTimeoutFromContext(ctx); err != nil {
+	ctx, _, restore, err := c.applyOptions(ctx, nil)
+	if err != nil {
 		return nil, err
 	}
+	defer restore()
 
 	done := make(chan struct{})
 	if ctx != nil {
@@ -98,7 +145,7 @@ func (c *Connection) Prepare(ctx context.Context, cypher string) (*PreparedState
 
 	ps, err := c.c.Prepare(cypher)
 	if err != nil {
-		return nil, fmt.Errorf("ladybug: %w", err)
+		return nil, wrapCtxErr(ctx, err)
 	}
 	if ctx != nil && ctx.Err() != nil {
 		ps.Close()
@@ -107,12 +154,100 @@ func (c *Connection) Prepare(ctx context.Context, cypher string) (*PreparedState
 	return &PreparedStatement{c: ps, conn: c, query: cypher}, nil
 }
 
-// SetQueryTimeout sets the query timeout (0 = no timeout).
+// NamedArg is a query parameter for QueryNamed.
+type NamedArg struct {
+	Name  string
+	Value any
+}
+
+// Named returns a NamedArg binding v to the parameter $name.
+func Named(name string, v any) NamedArg {
+	return NamedArg{Name: name, Value: v}
+}
+
+// QueryParams prepares cypher, binds each entry of params with PreparedStatement.Bind and
+// executes it with opts. The statement is released (or returned to the statement cache, see
+// Config.StatementCacheSize) when the Result is closed. Caller must call Result.Close.
+func (c *Connection) QueryParams(ctx context.Context, cypher string, params map[string]any, opts ...QueryOption) (*Result, error) {
+	ps, err := c.prepareCached(ctx, cypher, slices.Collect(maps.Keys(params)))
+	if err != nil {
+		return nil, err
+	}
+	for name, v := range params {
+		if err := ps.Bind(name, v); err != nil {
+			ps.Close()
+			return nil, err
+		}
+	}
+	return executeOwned(ctx, ps, opts...)
+}
+
+// QueryNamed is QueryParams with variadic arguments (use QueryParams to pass QueryOptions):
+//
+//	res, err := conn.QueryNamed(ctx, "MATCH (p:Person) WHERE p.age > $min RETURN p.name", ladybug.Named("min", 18))
+func (c *Connection) QueryNamed(ctx context.Context, cypher string, args ...NamedArg) (*Result, error) {
+	names := make([]string, len(args))
+	for i, a := range args {
+		names[i] = a.Name
+	}
+	ps, err := c.prepareCached(ctx, cypher, names)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range args {
+		if err := ps.Bind(a.Name, a.Value); err != nil {
+			ps.Close()
+			return nil, err
+		}
+	}
+	return executeOwned(ctx, ps)
+}
+
+// executeOwned executes ps and hands it to the Result, which closes it.
+func executeOwned(ctx context.Context, ps *PreparedStatement, opts ...QueryOption) (*Result, error) {
+	res, err := ps.Execute(ctx, opts...)
+	if err != nil {
+		ps.Close()
+		return nil, err
+	}
+	res.stmt = ps
+	return res, nil
+}
+
+// SetMaxThreads sets the maximum number of threads used to execute queries on this
+// connection. The initial limit is Config.MaxNumThreads.
+func (c *Connection) SetMaxThreads(n uint64) error {
+	if c == nil || c.c == nil {
+		return ErrInvalidConn
+	}
+	if n == 0 {
+		return fmt.Errorf("ladybug: max threads must be positive")
+	}
+	_ = c.mu.lock(nil, true)
+	defer c.mu.unlock(true)
+	return wrapErr(c.c.SetMaxNumThreadForExec(n))
+}
+
+// MaxThreads returns the maximum number of threads used to execute queries on this connection.
+func (c *Connection) MaxThreads() (uint64, error) {
+	if c == nil || c.c == nil {
+		return 0, ErrInvalidConn
+	}
+	n, err := c.c.MaxNumThreadForExec()
+	return n, wrapErr(err)
+}
+
+// SetQueryTimeout sets the query timeout (0 = no timeout). WithTimeout and context
+// deadlines shorten it for a single call.
 func (c *Connection) SetQueryTimeout(d time.Duration) {
 	if c == nil || c.c == nil {
 		return
 	}
-	_ = c.c.SetQueryTimeout(uint64(d.Milliseconds()))
+	_ = c.mu.lock(nil, true)
+	defer c.mu.unlock(true)
+	if err := c.c.SetQueryTimeout(uint64(d.Milliseconds())); err == nil {
+		c.timeout = d
+	}
 }
 
 // Interrupt interrupts the current query on this connection.
@@ -123,19 +258,16 @@ func (c *Connection) Interrupt() {
 	c.c.Interrupt()
 }
 
-func (c *Connection) setTimeoutFromContext(ctx context.Context) error {
-	if ctx == nil {
-		return nil
-	}
-	deadline, ok := ctx.Deadline()
-	if !ok {
-		return nil
+// reset rolls back an open transaction and restores the settings a pooled connection may
+// have been left with.
+func (c *Connection) reset() {
+	if tx := c.tx.Load(); tx != nil {
+		_ = tx.Rollback()
 	}
-	ms := time.Until(deadline).Milliseconds()
-	if ms < 0 {
-		ms = 0
+	c.SetQueryTimeout(0)
+	if c.base.threads != 0 {
+		_ = c.c.SetMaxNumThreadForExec(c.base.threads)
 	}
-	return c.c.SetQueryTimeout(uint64(ms))
 }
 
 func invokeQueryHook(cfg *Config, ctx context.Context, cypher string, summary QuerySummary, err error) {
@@ -0,0 +1,80 @@
+package ladybug
+
+import (
+	"context"
+	"sync"
+)
+
+// connLock is a reader/writer lock whose waiters give up when their context ends. Waiting
+// exclusive holders block new shared ones, so a stream of shared calls cannot starve them.
+// The zero value is unlocked.
+type connLock struct {
+	mu      sync.Mutex
+	readers int
+	writer  bool
+	waiting int           // exclusive holders waiting
+	changed chan struct{} // closed when the state changes; nil until someone waits
+}
+
+// lock acquires l, exclusively or shared, or returns ctx.Err() if ctx ends first. A nil ctx
+// waits indefinitely.
+func (l *connLock) lock(ctx context.Context, exclusive bool) error {
+	var done <-chan struct{}
+	if ctx != nil {
+		done = ctx.Done()
+	}
+	l.mu.Lock()
+	if exclusive {
+		l.waiting++
+	}
+	for {
+		if exclusive && !l.writer && l.readers == 0 {
+			l.waiting--
+			l.writer = true
+			l.mu.Unlock()
+			return nil
+		}
+		if !exclusive && !l.writer && l.waiting == 0 {
+			l.readers++
+			l.mu.Unlock()
+			return nil
+		}
+		if l.changed == nil {
+			l.changed = make(chan struct{})
+		}
+		changed := l.changed
+		l.mu.Unlock()
+		select {
+		case <-changed:
+			l.mu.Lock()
+		case <-done:
+			l.mu.Lock()
+			if exclusive {
+				l.waiting--
+				l.notify()
+			}
+			l.mu.Unlock()
+			return ctx.Err()
+		}
+	}
+}
+
+// unlock releases l, which the caller acquired with lock(_, exclusive).
+func (l *connLock) unlock(exclusive bool) {
+	l.mu.Lock()
+	if exclusive {
+		l.writer = false
+	} else {
+		l.readers--
+	}
+	l.notify()
+	l.mu.Unlock()
+}
+
+// notify wakes every waiter. l.mu must be held.
+func (l *connLock) notify() {
+	if l.changed != nil {
+		close(l.changed)
+		l.changed = nil
+	}
+}
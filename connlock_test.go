@@ -0,0 +1,56 @@
+package ladybug
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestConnLock(t *testing.T) {
+	var l connLock
+	if err := l.lock(nil, false); err != nil {
+		t.Fatal(err)
+	}
+
+	// An exclusive waiter gives up when its context ends.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := l.lock(ctx, true); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("exclusive lock behind shared holder: err = %v", err)
+	}
+	// Its departure lets shared holders in again.
+	if err := l.lock(nil, false); err != nil {
+		t.Fatal(err)
+	}
+	l.unlock(false)
+
+	// A waiting exclusive holder blocks new shared ones and gets the lock when the shared
+	// holder leaves.
+	acquired := make(chan struct{})
+	go func() {
+		_ = l.lock(nil, true)
+		close(acquired)
+	}()
+	for {
+		l.mu.Lock()
+		waiting := l.waiting
+		l.mu.Unlock()
+		if waiting == 1 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := l.lock(ctx, false); err == nil {
+		t.Fatal("shared lock acquired while an exclusive holder was waiting")
+	}
+	l.unlock(false)
+	<-acquired
+	l.unlock(true)
+	if err := l.lock(nil, true); err != nil {
+		t.Fatal(err)
+	}
+	l.unlock(true)
+}
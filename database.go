@@ -14,6 +14,8 @@ type Database struct {
 }
 
 // Open opens or creates a database at path. If opts is nil, path is used and other options are default.
+// Non-zero Config fields override the corresponding lbug_system_config defaults; invalid
+// combinations are rejected before the library is called.
 // Compatible Ladybug version: see README (e.g. v0.14.2-bindings.0).
 func Open(ctx context.Context, path string, opts *Config) (*Database, error) {
 	if path == "" && (opts == nil || opts.Path == "") {
@@ -28,9 +30,13 @@ func Open(ctx context.Context, path string, opts *Config) (*Database, error) {
 	}
 	cfg.Path = path
 
-	cDB, err := lbugc.Open(path)
+	sc, err := cfg.systemConfig()
 	if err != nil {
-		return nil, fmt.Errorf("ladybug: %w", err)
+		return nil, err
+	}
+	cDB, err := lbugc.Open(path, sc)
+	if err != nil {
+		return nil, wrapErr(err)
 	}
 	return &Database{c: cDB, cfg: cfg}, nil
 }
@@ -53,7 +59,11 @@ func (db *Database) Conn(ctx context.Context) (*Connection, error) {
 	}
 	cConn, err := db.c.Conn()
 	if err != nil {
-		return nil, fmt.Errorf("ladybug: %w", err)
+		return nil, wrapErr(err)
+	}
+	conn := &Connection{c: cConn, cfg: &db.cfg}
+	if db.cfg.StatementCacheSize > 0 {
+		conn.stmts = newStmtCache(db.cfg.StatementCacheSize)
 	}
-	return &Connection{c: cConn, cfg: &db.cfg}, nil
+	return conn, nil
 }
@@ -0,0 +1,176 @@
+package ladybug
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Decimal is an exact decimal number, Unscaled × 10^-Scale, as stored in DECIMAL columns.
+// The scale of a value read from Ladybug is the column's, so 12.50 in a DECIMAL(10, 2)
+// column is {1250, 2}.
+//
+// Ladybug has no DECIMAL parameter type: a Decimal binds as its STRING form, which the query
+// casts to the column type, e.g. CAST($amount AS DECIMAL(18, 2)).
+type Decimal struct {
+	Unscaled *big.Int
+	Scale    int32
+}
+
+// ParseDecimal parses a decimal number such as "-12.50". The scale is the number of digits
+// after the point.
+func ParseDecimal(s string) (Decimal, error) {
+	digits := strings.TrimSpace(s)
+	neg := strings.HasPrefix(digits, "-")
+	if neg || strings.HasPrefix(digits, "+") {
+		digits = digits[1:]
+	}
+	intPart, frac, _ := strings.Cut(digits, ".")
+	if intPart == "" && frac == "" || strings.ContainsAny(intPart+frac, "+-") {
+		return Decimal{}, fmt.Errorf("ladybug: invalid decimal %q", s)
+	}
+	u, ok := new(big.Int).SetString(intPart+frac, 10)
+	if !ok {
+		return Decimal{}, fmt.Errorf("ladybug: invalid decimal %q", s)
+	}
+	if neg {
+		u.Neg(u)
+	}
+	return Decimal{Unscaled: u, Scale: int32(len(frac))}, nil
+}
+
+// String returns the number in plain notation with Scale digits after the point.
+func (d Decimal) String() string {
+	if d.Unscaled == nil {
+		return "0"
+	}
+	if d.Scale <= 0 {
+		s := d.Unscaled.String()
+		if d.Scale < 0 && d.Unscaled.Sign() != 0 {
+			s += strings.Repeat("0", int(-d.Scale))
+		}
+		return s
+	}
+	digits := new(big.Int).Abs(d.Unscaled).String()
+	if n := int(d.Scale) + 1 - len(digits); n > 0 {
+		digits = strings.Repeat("0", n) + digits
+	}
+	point := len(digits) - int(d.Scale)
+	s := digits[:point] + "." + digits[point:]
+	if d.Unscaled.Sign() < 0 {
+		s = "-" + s
+	}
+	return s
+}
+
+// Rat returns the exact value of d.
+func (d Decimal) Rat() *big.Rat {
+	r := new(big.Rat)
+	if d.Unscaled == nil {
+		return r
+	}
+	r.SetInt(d.Unscaled)
+	pow := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs32(d.Scale))), nil)
+	if d.Scale > 0 {
+		return r.Quo(r, new(big.Rat).SetInt(pow))
+	}
+	return r.Mul(r, new(big.Rat).SetInt(pow))
+}
+
+// Float64 returns the nearest float64 to d.
+func (d Decimal) Float64() float64 {
+	f, _ := d.Rat().Float64()
+	return f
+}
+
+// Int returns d as an integer, and false if d has a fractional part.
+func (d Decimal) Int() (*big.Int, bool) {
+	r := d.Rat()
+	if !r.IsInt() {
+		return nil, false
+	}
+	return new(big.Int).Set(r.Num()), true
+}
+
+// Cmp compares d and e numerically, ignoring their scales.
+func (d Decimal) Cmp(e Decimal) int {
+	return d.Rat().Cmp(e.Rat())
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (d Decimal) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (d *Decimal) UnmarshalText(b []byte) error {
+	v, err := ParseDecimal(string(b))
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// Value implements driver.Valuer; a Decimal binds as its string form.
+func (d Decimal) Value() (driver.Value, error) {
+	return d.String(), nil
+}
+
+// Scan implements sql.Scanner for Decimal, string, []byte, integer and *big.Int sources.
+func (d *Decimal) Scan(src any) error {
+	switch v := src.(type) {
+	case Decimal:
+		u := new(big.Int)
+		if v.Unscaled != nil {
+			u.Set(v.Unscaled)
+		}
+		*d = Decimal{Unscaled: u, Scale: v.Scale}
+	case string:
+		return d.UnmarshalText([]byte(v))
+	case []byte:
+		return d.UnmarshalText(v)
+	case int64:
+		*d = Decimal{Unscaled: big.NewInt(v)}
+	case uint64:
+		*d = Decimal{Unscaled: new(big.Int).SetUint64(v)}
+	case *big.Int:
+		*d = Decimal{Unscaled: new(big.Int).Set(v)}
+	default:
+		return fmt.Errorf("ladybug: cannot scan %T into Decimal", src)
+	}
+	return nil
+}
+
+func abs32(x int32) int32 {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+var (
+	int128Min = new(big.Int).Lsh(big.NewInt(-1), 127)
+	int128Max = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 127), big.NewInt(1))
+)
+
+// fitsInt128 reports whether i is in the range of INT128.
+func fitsInt128(i *big.Int) bool {
+	return i.Cmp(int128Min) >= 0 && i.Cmp(int128Max) <= 0
+}
+
+// bigIntOf converts the integer driver values into a big.Int.
+func bigIntOf(v any) (*big.Int, bool) {
+	switch x := v.(type) {
+	case *big.Int:
+		return new(big.Int).Set(x), true
+	case int64:
+		return big.NewInt(x), true
+	case uint64:
+		return new(big.Int).SetUint64(x), true
+	case Decimal:
+		return x.Int()
+	}
+	return nil, false
+}
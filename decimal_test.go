@@ -0,0 +1,130 @@
+package ladybug
+
+import (
+	"context"
+	"math/big"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestDecimal(t *testing.T) {
+	for _, tc := range []struct {
+		in       string
+		unscaled int64
+		scale    int32
+		out      string
+	}{
+		{"12.50", 1250, 2, "12.50"},
+		{"-0.05", -5, 2, "-0.05"},
+		{"+7", 7, 0, "7"},
+		{".5", 5, 1, "0.5"},
+		{"100", 100, 0, "100"},
+	} {
+		d, err := ParseDecimal(tc.in)
+		if err != nil {
+			t.Errorf("ParseDecimal(%q): %v", tc.in, err)
+			continue
+		}
+		if d.Unscaled.Int64() != tc.unscaled || d.Scale != tc.scale {
+			t.Errorf("ParseDecimal(%q) = {%s, %d}", tc.in, d.Unscaled, d.Scale)
+		}
+		if s := d.String(); s != tc.out {
+			t.Errorf("String(%q) = %q, want %q", tc.in, s, tc.out)
+		}
+	}
+	for _, bad := range []string{"", "-", "1.2.3", "--1", "1e5", "abc"} {
+		if _, err := ParseDecimal(bad); err == nil {
+			t.Errorf("ParseDecimal(%q) succeeded", bad)
+		}
+	}
+	if s := (Decimal{Unscaled: big.NewInt(12), Scale: -2}).String(); s != "1200" {
+		t.Errorf("negative scale String = %q", s)
+	}
+	a, _ := ParseDecimal("1.10")
+	b, _ := ParseDecimal("1.1")
+	if a.Cmp(b) != 0 {
+		t.Error("1.10 != 1.1")
+	}
+	if f := a.Float64(); f != 1.1 {
+		t.Errorf("Float64 = %v", f)
+	}
+	if _, ok := a.Int(); ok {
+		t.Error("Int of 1.10 succeeded")
+	}
+
+	// Scan and struct decoding.
+	var dst struct {
+		D Decimal
+		F float64
+		I big.Int
+		P *big.Int
+		N int64
+	}
+	huge, _ := new(big.Int).SetString("170141183460469231731687303715884105727", 10)
+	for name, v := range map[string]any{"D": a, "F": a, "I": huge, "P": huge, "N": big.NewInt(42)} {
+		f, _ := reflect.TypeOf(dst).FieldByName(name)
+		if err := assignValue(reflect.ValueOf(&dst).Elem().FieldByIndex(f.Index), v); err != nil {
+			t.Errorf("assign %s: %v", name, err)
+		}
+	}
+	if dst.D.Cmp(a) != 0 || dst.F != 1.1 || dst.I.Cmp(huge) != 0 || dst.P.Cmp(huge) != 0 || dst.N != 42 {
+		t.Errorf("decoded = %+v", dst)
+	}
+	if err := assignValue(reflect.ValueOf(&dst.N).Elem(), huge); err == nil {
+		t.Error("expected overflow assigning INT128 max to int64")
+	}
+	if !fitsInt128(huge) || fitsInt128(new(big.Int).Add(huge, big.NewInt(1))) {
+		t.Error("fitsInt128 bounds are wrong")
+	}
+}
+
+func TestDecimalInt128RoundTrip(t *testing.T) {
+	ver, _ := Version()
+	if ver == "" {
+		t.Skip("Ladybug libs not available; skipping")
+	}
+	ctx := context.Background()
+	db, err := Open(ctx, filepath.Join(t.TempDir(), "testdb"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	amount, _ := ParseDecimal("1234567890123.45")
+	got, err := QueryOne[Decimal](ctx, conn, "RETURN CAST($a AS DECIMAL(18, 2))", map[string]any{"a": amount})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.String() != "1234567890123.45" || got.Scale != 2 {
+		t.Errorf("decimal = %s (scale %d)", got, got.Scale)
+	}
+
+	for _, s := range []string{"-170141183460469231731687303715884105728", "-1", "18446744073709551616"} {
+		want, _ := new(big.Int).SetString(s, 10)
+		res, err := conn.QueryParams(ctx, "RETURN $i", map[string]any{"i": want})
+		if err != nil {
+			t.Fatal(err)
+		}
+		row, ok := res.Next()
+		if !ok {
+			t.Fatal("expected one row")
+		}
+		var i big.Int
+		if err := row.Scan(&i); err != nil {
+			t.Fatal(err)
+		}
+		res.Close()
+		if i.Cmp(want) != 0 {
+			t.Errorf("INT128 round trip = %s, want %s", &i, want)
+		}
+	}
+	if _, err := conn.QueryParams(ctx, "RETURN $i", map[string]any{"i": new(big.Int).Lsh(big.NewInt(1), 127)}); err == nil {
+		t.Error("expected INT128 overflow error")
+	}
+}
@@ -0,0 +1,504 @@
+package ladybug
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DriverName is the name the database/sql driver is registered under.
+const DriverName = "ladybug"
+
+func init() {
+	sql.Register(DriverName, &Driver{})
+}
+
+var (
+	_ driver.Driver                         = (*Driver)(nil)
+	_ driver.DriverContext                  = (*Driver)(nil)
+	_ driver.Connector                      = (*Connector)(nil)
+	_ driver.Conn                           = (*sqlConn)(nil)
+	_ driver.ConnBeginTx                    = (*sqlConn)(nil)
+	_ driver.ConnPrepareContext             = (*sqlConn)(nil)
+	_ driver.QueryerContext                 = (*sqlConn)(nil)
+	_ driver.ExecerContext                  = (*sqlConn)(nil)
+	_ driver.NamedValueChecker              = (*sqlConn)(nil)
+	_ driver.Pinger                         = (*sqlConn)(nil)
+	_ driver.SessionResetter                = (*sqlConn)(nil)
+	_ driver.Validator                      = (*sqlConn)(nil)
+	_ driver.Stmt                           = (*sqlStmt)(nil)
+	_ driver.StmtExecContext                = (*sqlStmt)(nil)
+	_ driver.StmtQueryContext               = (*sqlStmt)(nil)
+	_ driver.Rows                           = (*sqlRows)(nil)
+	_ driver.RowsColumnTypeDatabaseTypeName = (*sqlRows)(nil)
+	_ driver.RowsNextResultSet              = (*sqlRows)(nil)
+)
+
+// Driver implements database/sql/driver.Driver for Ladybug. It is registered as "ladybug".
+//
+// The DSN is a database path optionally followed by "?key=value&..." options mapping to Config:
+// read_only, buffer_pool_size, max_num_threads, disable_compression, max_db_size,
+// disable_auto_checkpoint, checkpoint_threshold, statement_cache_size and location (an IANA
+// time zone name such as "Europe/Berlin"). For example:
+//
+//	db, err := sql.Open("ladybug", "/data/graph?read_only=true&max_num_threads=4")
+//
+// Parameters are bound by name ($name via sql.Named("name", v)); positional arguments
+// are bound as $1, $2, ... NODE, REL and RECURSIVE_REL columns scan into Node, Rel and Path;
+// INTERVAL columns scan into Interval. DATE columns are returned as midnight UTC and scan into
+// time.Time or Date; timestamps are rendered in the location set with the "location" option.
+// DECIMAL and INT128 columns are returned as decimal strings and scan into string or Decimal.
+type Driver struct{}
+
+// Open opens a standalone connection that owns its own Database.
+// database/sql uses OpenConnector instead, which shares one Database across connections.
+func (d *Driver) Open(dsn string) (driver.Conn, error) {
+	cfg, err := parseDSN(dsn)
+	if err != nil {
+		return nil, err
+	}
+	ctx := context.Background()
+	db, err := Open(ctx, "", cfg)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &sqlConn{conn: conn, db: db}, nil
+}
+
+// OpenConnector parses dsn and returns a Connector that opens the database on first use.
+func (d *Driver) OpenConnector(dsn string) (driver.Connector, error) {
+	cfg, err := parseDSN(dsn)
+	if err != nil {
+		return nil, err
+	}
+	return &Connector{cfg: cfg, ownsDB: true}, nil
+}
+
+// Connector implements driver.Connector. Use NewConnector with sql.OpenDB to share an
+// already-open Database with database/sql.
+type Connector struct {
+	mu     sync.Mutex
+	cfg    *Config
+	db     *Database
+	ownsDB bool
+}
+
+// NewConnector returns a Connector backed by db. The caller keeps ownership of db and must
+// close it after the *sql.DB that uses the connector.
+func NewConnector(db *Database) *Connector {
+	return &Connector{db: db}
+}
+
+// Connect returns a new connection to the database, opening it first if needed.
+func (c *Connector) Connect(ctx context.Context) (driver.Conn, error) {
+	c.mu.Lock()
+	if c.db == nil {
+		if !c.ownsDB {
+			c.mu.Unlock()
+			return nil, ErrClosed
+		}
+		db, err := Open(ctx, "", c.cfg)
+		if err != nil {
+			c.mu.Unlock()
+			return nil, err
+		}
+		c.db = db
+	}
+	db := c.db
+	c.mu.Unlock()
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &sqlConn{conn: conn}, nil
+}
+
+// Driver returns the Ladybug driver.
+func (c *Connector) Driver() driver.Driver {
+	return &Driver{}
+}
+
+// Close closes the database if the connector opened it. sql.DB.Close calls this.
+func (c *Connector) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.ownsDB || c.db == nil {
+		return nil
+	}
+	err := c.db.Close()
+	c.db = nil
+	return err
+}
+
+func parseDSN(dsn string) (*Config, error) {
+	path, rawQuery, _ := strings.Cut(dsn, "?")
+	if path == "" {
+		return nil, fmt.Errorf("ladybug: DSN %q has no database path", dsn)
+	}
+	cfg := &Config{Path: path}
+	q, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return nil, fmt.Errorf("ladybug: invalid DSN options: %w", err)
+	}
+	for key, vals := range q {
+		val := vals[len(vals)-1]
+		var err error
+		switch key {
+		case "read_only":
+			cfg.ReadOnly, err = strconv.ParseBool(val)
+		case "disable_compression":
+			cfg.DisableCompression, err = strconv.ParseBool(val)
+		case "disable_auto_checkpoint":
+			cfg.DisableAutoCheckpoint, err = strconv.ParseBool(val)
+		case "buffer_pool_size":
+			cfg.BufferPoolSize, err = strconv.ParseUint(val, 10, 64)
+		case "max_num_threads":
+			cfg.MaxNumThreads, err = strconv.ParseUint(val, 10, 64)
+		case "max_db_size":
+			cfg.MaxDBSize, err = strconv.ParseUint(val, 10, 64)
+		case "checkpoint_threshold":
+			cfg.CheckpointThreshold, err = strconv.ParseUint(val, 10, 64)
+		case "statement_cache_size":
+			cfg.StatementCacheSize, err = strconv.Atoi(val)
+		case "location":
+			cfg.Location, err = time.LoadLocation(val)
+		default:
+			return nil, fmt.Errorf("ladybug: unknown DSN option %q", key)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("ladybug: invalid DSN option %s=%q: %w", key, val, err)
+		}
+	}
+	return cfg, nil
+}
+
+// sqlConn adapts a Connection to driver.Conn.
+type sqlConn struct {
+	conn *Connection
+	// db is set when the connection owns its Database (Driver.Open).
+	db *Database
+}
+
+func (c *sqlConn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+func (c *sqlConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	ps, err := c.conn.Prepare(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return &sqlStmt{ps: ps}, nil
+}
+
+func (c *sqlConn) Close() error {
+	err := c.conn.Close()
+	if c.db != nil {
+		c.db.Close()
+		c.db = nil
+	}
+	return err
+}
+
+func (c *sqlConn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *sqlConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if sql.IsolationLevel(opts.Isolation) != sql.LevelDefault {
+		return nil, fmt.Errorf("ladybug: isolation level %s is not supported", sql.IsolationLevel(opts.Isolation))
+	}
+	stmt := "BEGIN TRANSACTION"
+	if opts.ReadOnly {
+		stmt += " READ ONLY"
+	}
+	if err := c.exec(ctx, stmt); err != nil {
+		return nil, err
+	}
+	return &sqlTx{c: c}, nil
+}
+
+func (c *sqlConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if len(args) == 0 {
+		res, err := c.conn.Query(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		return newSQLRows(res, nil)
+	}
+	names := make([]string, len(args))
+	for i, a := range args {
+		names[i] = argName(a)
+	}
+	ps, err := c.conn.prepareCached(ctx, query, names)
+	if err != nil {
+		return nil, err
+	}
+	res, err := executeNamed(ctx, ps, args)
+	if err != nil {
+		ps.Close()
+		return nil, err
+	}
+	return newSQLRows(res, ps)
+}
+
+func (c *sqlConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	rows, err := c.QueryContext(ctx, query, args)
+	if err != nil {
+		return nil, err
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	return sqlResult{}, nil
+}
+
+// CheckNamedValue passes every argument to PreparedStatement.Bind, which converts
+// composite values (slices, maps, structs) itself. driver.Valuer implementations are
+// left to the database/sql default converter.
+func (c *sqlConn) CheckNamedValue(nv *driver.NamedValue) error {
+	if _, ok := nv.Value.(driver.Valuer); ok {
+		return driver.ErrSkip
+	}
+	return nil
+}
+
+func (c *sqlConn) Ping(ctx context.Context) error {
+	if c.conn == nil || c.conn.c == nil {
+		return driver.ErrBadConn
+	}
+	return c.exec(ctx, "RETURN 1")
+}
+
+func (c *sqlConn) ResetSession(ctx context.Context) error {
+	if !c.IsValid() {
+		return driver.ErrBadConn
+	}
+	return nil
+}
+
+func (c *sqlConn) IsValid() bool {
+	return c.conn != nil && c.conn.c != nil
+}
+
+func (c *sqlConn) exec(ctx context.Context, query string) error {
+	res, err := c.conn.Query(ctx, query)
+	if err != nil {
+		return err
+	}
+	return res.Close()
+}
+
+func executeNamed(ctx context.Context, ps *PreparedStatement, args []driver.NamedValue) (*Result, error) {
+	for _, a := range args {
+		if err := ps.Bind(argName(a), a.Value); err != nil {
+			return nil, err
+		}
+	}
+	return ps.Execute(ctx)
+}
+
+// argName returns the parameter an argument binds: its name, or its ordinal for $1, $2, ...
+func argName(a driver.NamedValue) string {
+	if a.Name != "" {
+		return a.Name
+	}
+	return strconv.Itoa(a.Ordinal)
+}
+
+// sqlStmt adapts a PreparedStatement to driver.Stmt.
+type sqlStmt struct {
+	ps *PreparedStatement
+}
+
+func (s *sqlStmt) Close() error {
+	return s.ps.Close()
+}
+
+// NumInput returns -1: parameter names are not exposed by the C API.
+func (s *sqlStmt) NumInput() int {
+	return -1
+}
+
+func (s *sqlStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return s.ExecContext(context.Background(), namedValues(args))
+}
+
+func (s *sqlStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return s.QueryContext(context.Background(), namedValues(args))
+}
+
+func (s *sqlStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	res, err := executeNamed(ctx, s.ps, args)
+	if err != nil {
+		return nil, err
+	}
+	if err := res.Close(); err != nil {
+		return nil, err
+	}
+	return sqlResult{}, nil
+}
+
+func (s *sqlStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	res, err := executeNamed(ctx, s.ps, args)
+	if err != nil {
+		return nil, err
+	}
+	return newSQLRows(res, nil)
+}
+
+func namedValues(args []driver.Value) []driver.NamedValue {
+	out := make([]driver.NamedValue, len(args))
+	for i, v := range args {
+		out[i] = driver.NamedValue{Ordinal: i + 1, Value: v}
+	}
+	return out
+}
+
+// sqlRows adapts a Result to driver.Rows.
+type sqlRows struct {
+	res *Result
+	// ps is closed with the rows when the statement was prepared for a single query.
+	ps    *PreparedStatement
+	names []string
+	types []TypeID
+}
+
+func newSQLRows(res *Result, ps *PreparedStatement) (*sqlRows, error) {
+	r := &sqlRows{res: res, ps: ps}
+	if err := r.loadColumns(); err != nil {
+		r.Close()
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *sqlRows) loadColumns() error {
+	cols, err := r.res.Columns()
+	if err != nil {
+		return err
+	}
+	r.names = make([]string, len(cols))
+	r.types = make([]TypeID, len(cols))
+	for i, col := range cols {
+		r.names[i] = col.Name
+		r.types[i] = col.Type.ID
+	}
+	return nil
+}
+
+func (r *sqlRows) Columns() []string {
+	return r.names
+}
+
+func (r *sqlRows) Close() error {
+	err := r.res.Close()
+	if r.ps != nil {
+		r.ps.Close()
+		r.ps = nil
+	}
+	return err
+}
+
+func (r *sqlRows) Next(dest []driver.Value) error {
+	row, ok := r.res.Next()
+	if !ok {
+		if err := r.res.Err(); err != nil {
+			return err
+		}
+		return io.EOF
+	}
+	for i := range dest {
+		v, err := row.Value(uint64(i))
+		if err != nil {
+			return err
+		}
+		switch r.types[i] {
+		case TypeNode:
+			if n, ok := AsNode(v); ok {
+				v = n
+			}
+		case TypeRel:
+			if rel, ok := AsRel(v); ok {
+				v = rel
+			}
+		case TypeRecursiveRel:
+			if p, ok := AsPath(v); ok {
+				v = p
+			}
+		case TypeDate:
+			if d, ok := v.(Date); ok {
+				v = d.In(time.UTC)
+			}
+		case TypeDecimal:
+			if d, ok := v.(Decimal); ok {
+				v = d.String()
+			}
+		case TypeInt128:
+			if n, ok := v.(*big.Int); ok {
+				v = n.String()
+			}
+		}
+		dest[i] = v
+	}
+	return nil
+}
+
+// ColumnTypeDatabaseTypeName returns the Ladybug type name, e.g. "INT64", "STRING" or "NODE".
+func (r *sqlRows) ColumnTypeDatabaseTypeName(index int) string {
+	return r.types[index].String()
+}
+
+func (r *sqlRows) HasNextResultSet() bool {
+	return r.res.HasNextResultSet()
+}
+
+func (r *sqlRows) NextResultSet() error {
+	ok, err := r.res.NextResultSet()
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return io.EOF
+	}
+	return r.loadColumns()
+}
+
+// sqlTx runs COMMIT/ROLLBACK on the owning connection.
+type sqlTx struct {
+	c *sqlConn
+}
+
+func (tx *sqlTx) Commit() error {
+	return tx.c.exec(context.Background(), "COMMIT")
+}
+
+func (tx *sqlTx) Rollback() error {
+	return tx.c.exec(context.Background(), "ROLLBACK")
+}
+
+// sqlResult is returned by Exec. Ladybug does not report affected rows or insert ids.
+type sqlResult struct{}
+
+func (sqlResult) LastInsertId() (int64, error) {
+	return 0, errors.New("ladybug: LastInsertId is not supported")
+}
+
+func (sqlResult) RowsAffected() (int64, error) {
+	return 0, errors.New("ladybug: RowsAffected is not supported")
+}
@@ -0,0 +1,121 @@
+package ladybug
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseDSN(t *testing.T) {
+	cfg, err := parseDSN("/data/graph?read_only=true&buffer_pool_size=1048576&max_num_threads=4&statement_cache_size=16&location=UTC")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Path != "/data/graph" || !cfg.ReadOnly || cfg.BufferPoolSize != 1<<20 || cfg.MaxNumThreads != 4 || cfg.StatementCacheSize != 16 || cfg.Location != time.UTC {
+		t.Errorf("parseDSN() = %+v", cfg)
+	}
+	for _, dsn := range []string{"", "?read_only=true", "/data/graph?bogus=1", "/data/graph?max_num_threads=-1", "/data/graph?location=Nowhere/Nothing"} {
+		if _, err := parseDSN(dsn); err == nil {
+			t.Errorf("parseDSN(%q) should fail", dsn)
+		}
+	}
+}
+
+func TestSQLDriver(t *testing.T) {
+	ver, _ := Version()
+	if ver == "" {
+		t.Skip("Ladybug libs not available; skipping")
+	}
+	ctx := context.Background()
+	db, err := sql.Open(DriverName, filepath.Join(t.TempDir(), "sqldb"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if err := db.PingContext(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := db.ExecContext(ctx, "CREATE NODE TABLE Person(name STRING, age INT64, PRIMARY KEY(name))"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.ExecContext(ctx, "CREATE (:Person {name: $name, age: $age})",
+		sql.Named("name", "alice"), sql.Named("age", 30)); err != nil {
+		t.Fatal(err)
+	}
+
+	rows, err := db.QueryContext(ctx, "MATCH (p:Person) WHERE p.age > $min RETURN p, p.name AS name", sql.Named("min", 18))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	cols, err := rows.Columns()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cols) != 2 || cols[1] != "name" {
+		t.Errorf("Columns() = %v", cols)
+	}
+	types, err := rows.ColumnTypes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := types[0].DatabaseTypeName(); got != "NODE" {
+		t.Errorf("DatabaseTypeName(0) = %q, want NODE", got)
+	}
+	if !rows.Next() {
+		t.Fatal("expected row")
+	}
+	var (
+		n    Node
+		name string
+	)
+	if err := rows.Scan(&n, &name); err != nil {
+		t.Fatal(err)
+	}
+	if name != "alice" || n.Properties["age"] != int64(30) {
+		t.Errorf("got node %+v name %q", n, name)
+	}
+	if rows.Next() {
+		t.Error("expected one row")
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tx.ExecContext(ctx, "CREATE (:Person {name: 'bob', age: 5})"); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Rollback(); err != nil {
+		t.Fatal(err)
+	}
+	var count int64
+	if err := db.QueryRowContext(ctx, "MATCH (p:Person) RETURN count(p)").Scan(&count); err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 {
+		t.Errorf("count after rollback = %d, want 1", count)
+	}
+
+	const wide = "CAST('12.50' AS DECIMAL(10, 2)), CAST('170141183460469231731687303715884105727' AS INT128)"
+	var ds, is string
+	if err := db.QueryRowContext(ctx, "RETURN "+wide).Scan(&ds, &is); err != nil {
+		t.Fatal(err)
+	}
+	if ds != "12.50" || is != "170141183460469231731687303715884105727" {
+		t.Errorf("DECIMAL, INT128 as string = %q, %q", ds, is)
+	}
+	var dd, id Decimal
+	if err := db.QueryRowContext(ctx, "RETURN "+wide).Scan(&dd, &id); err != nil {
+		t.Fatal(err)
+	}
+	if dd.String() != "12.50" || id.String() != is {
+		t.Errorf("DECIMAL, INT128 as Decimal = %s, %s", dd, id)
+	}
+}
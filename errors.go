@@ -1,10 +1,185 @@
 package ladybug
 
-import "errors"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/vkozio/ladybug-go-zero/internal/lbugc"
+)
 
 var (
 	// ErrClosed is returned when an operation is performed on a closed Database, Connection, Result, or PreparedStatement.
 	ErrClosed = errors.New("ladybug: closed")
 	// ErrInvalidConn is returned when the connection is invalid or closed.
 	ErrInvalidConn = errors.New("ladybug: invalid connection")
+	// ErrNoRows is returned by QueryOne when the query returns no rows.
+	ErrNoRows = errors.New("ladybug: no rows in result set")
+	// ErrTooManyRows is returned by QueryOne when the query returns more than one row.
+	ErrTooManyRows = errors.New("ladybug: more than one row in result set")
+	// ErrTxDone is returned when a Tx is used after Commit or Rollback.
+	ErrTxDone = errors.New("ladybug: transaction has already been committed or rolled back")
+)
+
+// Sentinels matching an *Error of the corresponding Kind with errors.Is.
+var (
+	ErrSyntax      = errors.New("ladybug: syntax error")
+	ErrBinder      = errors.New("ladybug: binder error")
+	ErrRuntime     = errors.New("ladybug: runtime error")
+	ErrCatalog     = errors.New("ladybug: catalog error")
+	ErrInterrupted = errors.New("ladybug: interrupted")
+	ErrTimeout     = errors.New("ladybug: timeout")
+	ErrTransaction = errors.New("ladybug: transaction error")
+	ErrConnection  = errors.New("ladybug: connection error")
 )
+
+// ErrorKind classifies an *Error.
+type ErrorKind int
+
+const (
+	KindUnknown ErrorKind = iota
+	// KindSyntax is a parser error (malformed Cypher).
+	KindSyntax
+	// KindBinder is a semantic error: unknown variable, property, function or type mismatch.
+	KindBinder
+	// KindRuntime is an error raised while executing a valid query (constraint violation, overflow, copy failure...).
+	KindRuntime
+	// KindCatalog is a schema error: unknown or duplicate table, property or index.
+	KindCatalog
+	// KindInterrupted means the query was interrupted (Connection.Interrupt or context cancellation).
+	KindInterrupted
+	// KindTimeout means the query exceeded its timeout or context deadline.
+	KindTimeout
+	// KindTransaction is a transaction error, e.g. a write-write conflict or invalid BEGIN/COMMIT.
+	KindTransaction
+	// KindConnection means the database or connection is unusable (closed, failed to open or connect).
+	KindConnection
+)
+
+var kindNames = [...]string{"Unknown", "Syntax", "Binder", "Runtime", "Catalog", "Interrupted", "Timeout", "Transaction", "Connection"}
+
+func (k ErrorKind) String() string {
+	if k >= 0 && int(k) < len(kindNames) {
+		return kindNames[k]
+	}
+	return "ErrorKind(" + strconv.Itoa(int(k)) + ")"
+}
+
+var kindSentinels = map[ErrorKind]error{
+	KindSyntax:      ErrSyntax,
+	KindBinder:      ErrBinder,
+	KindRuntime:     ErrRuntime,
+	KindCatalog:     ErrCatalog,
+	KindInterrupted: ErrInterrupted,
+	KindTimeout:     ErrTimeout,
+	KindTransaction: ErrTransaction,
+	KindConnection:  ErrConnection,
+}
+
+// Error is a failure reported by Ladybug. Use errors.As to inspect it, or errors.Is with
+// ErrSyntax, ErrBinder, ErrRuntime, ErrCatalog, ErrInterrupted, ErrTimeout, ErrTransaction
+// or ErrConnection to test its Kind.
+type Error struct {
+	Kind ErrorKind
+	// Op is the failing operation, e.g. "query", "prepare", "execute" or "database_init".
+	Op string
+	// Message is the library's error message, unmodified.
+	Message string
+	// Line and Column locate the error in the query text (1-based) when the message reports it; 0 otherwise.
+	Line   int
+	Column int
+
+	// cause is the context error for interrupts caused by cancellation or deadline.
+	cause error
+}
+
+func (e *Error) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("ladybug: %s failed", e.Op)
+	}
+	return fmt.Sprintf("ladybug: %s: %s", e.Op, e.Message)
+}
+
+// Is reports whether target is the sentinel for e.Kind.
+func (e *Error) Is(target error) bool {
+	s, ok := kindSentinels[e.Kind]
+	return ok && s == target
+}
+
+// Unwrap returns context.Canceled or context.DeadlineExceeded when the error was caused by the context.
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// wrapErr converts an internal lbugc error into an *Error. Other errors are returned unchanged.
+func wrapErr(err error) error {
+	var ce *lbugc.Error
+	if !errors.As(err, &ce) {
+		return err
+	}
+	e := &Error{Op: ce.Op, Message: ce.Message}
+	e.Kind = classify(ce.Op, ce.Message)
+	e.Line, e.Column = errorPosition(ce.Message)
+	return e
+}
+
+// wrapCtxErr is wrapErr for query paths: an interrupt observed while ctx is done is
+// reported as KindInterrupted or KindTimeout and unwraps to the context error.
+func wrapCtxErr(ctx context.Context, err error) error {
+	err = wrapErr(err)
+	var e *Error
+	if ctx == nil || ctx.Err() == nil || !errors.As(err, &e) {
+		return err
+	}
+	if e.Kind == KindInterrupted || e.Kind == KindTimeout || e.Kind == KindUnknown {
+		e.cause = ctx.Err()
+		if errors.Is(e.cause, context.DeadlineExceeded) {
+			e.Kind = KindTimeout
+		} else {
+			e.Kind = KindInterrupted
+		}
+	}
+	return err
+}
+
+// classify maps a library message to an ErrorKind using the exception prefixes Ladybug emits.
+func classify(op, msg string) ErrorKind {
+	m := strings.ToLower(msg)
+	switch {
+	case strings.HasPrefix(m, "parser exception"):
+		return KindSyntax
+	case strings.HasPrefix(m, "binder exception"):
+		return KindBinder
+	case strings.HasPrefix(m, "catalog exception"):
+		return KindCatalog
+	case strings.HasPrefix(m, "query timeout"), strings.HasPrefix(m, "timeout"):
+		return KindTimeout
+	case strings.HasPrefix(m, "interrupted"), strings.HasPrefix(m, "interrupt exception"):
+		return KindInterrupted
+	case strings.HasPrefix(m, "transaction"), strings.Contains(m, "write-write conflict"),
+		strings.Contains(m, "transaction manager"):
+		return KindTransaction
+	case strings.HasPrefix(m, "connection exception"), strings.Contains(m, "connection closed"),
+		strings.Contains(m, "database closed"), op == "database_init", op == "connection_init":
+		return KindConnection
+	case strings.Contains(m, "exception"):
+		return KindRuntime
+	}
+	return KindUnknown
+}
+
+var positionRe = regexp.MustCompile(`\(line:\s*(\d+),\s*offset:\s*(\d+)\)`)
+
+// errorPosition extracts "(line: L, offset: O)" from parser messages. The offset is 0-based.
+func errorPosition(msg string) (line, column int) {
+	m := positionRe.FindStringSubmatch(msg)
+	if m == nil {
+		return 0, 0
+	}
+	line, _ = strconv.Atoi(m[1])
+	offset, _ := strconv.Atoi(m[2])
+	return line, offset + 1
+}
@@ -54,5 +54,7 @@ func main() {
 		}
 		fmt.Printf("x=%d y=%s ts=%s\n", x, y, ts.Format(time.RFC3339Nano))
 	}
+	if err := res.Err(); err != nil {
+		log.Fatalf("rows: %v", err)
+	}
 }
-
@@ -0,0 +1,400 @@
+// Package export writes Ladybug query results to Parquet, Arrow IPC, CSV and NDJSON.
+//
+// Every writer reads the result through its Arrow path (Result.RecordReader), one record
+// batch at a time, so results larger than memory can be exported. Writers return the number
+// of rows written. When a writer returns an error the output is left unfinished: Parquet and
+// Arrow IPC output then lacks its footer or end-of-stream marker, so a truncated file fails
+// to read instead of passing for a complete one.
+package export
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/csv"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+	ladybug "github.com/vkozio/ladybug-go-zero"
+)
+
+// Nested selects how LIST, ARRAY, MAP, STRUCT, NODE and REL columns are written.
+type Nested int
+
+const (
+	// NestedDefault keeps nested columns as nested Arrow types for Parquet and Arrow IPC,
+	// writes them as JSON objects and arrays in NDJSON, and JSON-encodes them for CSV.
+	NestedDefault Nested = iota
+	// NestedJSON writes every nested column as a JSON-encoded string column.
+	NestedJSON
+	// NestedFlatten replaces STRUCT, NODE and REL columns with one column per field, named
+	// "column.field" (recursively). LIST, ARRAY and MAP columns are JSON-encoded.
+	NestedFlatten
+)
+
+// Options configures a writer. The zero value is valid.
+type Options struct {
+	// ChunkSize is the number of rows per Arrow record read from the result (0 = ladybug.DefaultArrowChunkSize).
+	ChunkSize int64
+	// Nested selects how nested columns are written.
+	Nested Nested
+	// IPCFile makes WriteArrowIPC write the Arrow IPC file format instead of the stream format.
+	IPCFile bool
+	// CSVDelimiter is the CSV field delimiter (0 = ',').
+	CSVDelimiter rune
+	// CSVNoHeader omits the CSV header row.
+	CSVNoHeader bool
+	// ParquetProperties overrides the Parquet writer properties (nil = defaults).
+	ParquetProperties *parquet.WriterProperties
+}
+
+func (o *Options) orDefault() *Options {
+	if o == nil {
+		return &Options{}
+	}
+	return o
+}
+
+// WriteParquet writes the remaining rows of res to w as a Parquet file.
+func WriteParquet(w io.Writer, res *ladybug.Result, opts *Options) (int64, error) {
+	opts = opts.orDefault()
+	return write(res, opts, nestedOr(opts.Nested, NestedDefault), parquetNative, func(sc *arrow.Schema) (recordWriter, error) {
+		props := opts.ParquetProperties
+		if props == nil {
+			props = parquet.NewWriterProperties()
+		}
+		fw, err := pqarrow.NewFileWriter(sc, w, props, pqarrow.DefaultWriterProps())
+		if err != nil {
+			return nil, err
+		}
+		return fw, nil
+	})
+}
+
+// WriteArrowIPC writes the remaining rows of res to w in the Arrow IPC stream format, or the
+// IPC file format when opts.IPCFile is set.
+func WriteArrowIPC(w io.Writer, res *ladybug.Result, opts *Options) (int64, error) {
+	opts = opts.orDefault()
+	return write(res, opts, nestedOr(opts.Nested, NestedDefault), allNative, func(sc *arrow.Schema) (recordWriter, error) {
+		if opts.IPCFile {
+			return ipc.NewFileWriter(w, ipc.WithSchema(sc))
+		}
+		return ipc.NewWriter(w, ipc.WithSchema(sc)), nil
+	})
+}
+
+// WriteCSV writes the remaining rows of res to w as CSV with a header row. Nested columns
+// are JSON-encoded unless opts.Nested is NestedFlatten; NULL is written as an empty field.
+func WriteCSV(w io.Writer, res *ladybug.Result, opts *Options) (int64, error) {
+	opts = opts.orDefault()
+	return write(res, opts, nestedOr(opts.Nested, NestedJSON), csvNative, func(sc *arrow.Schema) (recordWriter, error) {
+		cw := csv.NewWriter(w, sc, csv.WithHeader(!opts.CSVNoHeader), csv.WithComma(delimiter(opts.CSVDelimiter)), csv.WithNullWriter(""))
+		return csvWriter{cw}, nil
+	})
+}
+
+// WriteNDJSON writes the remaining rows of res to w as newline-delimited JSON, one object per
+// row with keys in column order.
+func WriteNDJSON(w io.Writer, res *ladybug.Result, opts *Options) (int64, error) {
+	opts = opts.orDefault()
+	bw := bufio.NewWriter(w)
+	return write(res, opts, nestedOr(opts.Nested, NestedDefault), allNative, func(sc *arrow.Schema) (recordWriter, error) {
+		return newNDJSONWriter(bw, sc)
+	})
+}
+
+func delimiter(r rune) rune {
+	if r == 0 {
+		return ','
+	}
+	return r
+}
+
+func nestedOr(n, def Nested) Nested {
+	if n == NestedDefault {
+		return def
+	}
+	return n
+}
+
+// recordWriter is the common subset of the arrow-go writers. Close finalizes the output;
+// write does not call it after an error.
+type recordWriter interface {
+	Write(rec arrow.RecordBatch) error
+	Close() error
+}
+
+// write streams res through the configured column conversion into the writer created by open.
+func write(res *ladybug.Result, opts *Options, nested Nested, native func(arrow.DataType) bool, open func(*arrow.Schema) (recordWriter, error)) (int64, error) {
+	rr, err := res.RecordReader(opts.ChunkSize)
+	if err != nil {
+		return 0, err
+	}
+	defer rr.Release()
+	conv := newConverter(rr.Schema(), nested, native)
+	rw, err := open(conv.schema)
+	if err != nil {
+		return 0, err
+	}
+	var rows int64
+	for rr.Next() {
+		rec, err := conv.convert(rr.RecordBatch())
+		if err != nil {
+			return rows, err
+		}
+		err = rw.Write(rec)
+		rec.Release()
+		if err != nil {
+			return rows, err
+		}
+		rows += rec.NumRows()
+	}
+	if err := rr.Err(); err != nil {
+		return rows, err
+	}
+	if err := rw.Close(); err != nil {
+		return rows, err
+	}
+	return rows, nil
+}
+
+func isNested(dt arrow.DataType) bool {
+	switch dt.ID() {
+	case arrow.LIST, arrow.LARGE_LIST, arrow.FIXED_SIZE_LIST, arrow.MAP, arrow.STRUCT,
+		arrow.SPARSE_UNION, arrow.DENSE_UNION:
+		return true
+	}
+	return false
+}
+
+func allNative(arrow.DataType) bool { return true }
+
+// parquetNative reports the leaf types pqarrow can store; others are written as strings.
+func parquetNative(dt arrow.DataType) bool {
+	switch dt.ID() {
+	case arrow.INTERVAL_MONTH_DAY_NANO, arrow.INTERVAL_DAY_TIME, arrow.INTERVAL_MONTHS,
+		arrow.DURATION, arrow.SPARSE_UNION, arrow.DENSE_UNION:
+		return false
+	case arrow.STRUCT:
+		return dt.(*arrow.StructType).NumFields() > 0
+	}
+	return true
+}
+
+// csvNative reports the types the arrow CSV writer formats itself.
+func csvNative(dt arrow.DataType) bool {
+	switch dt.ID() {
+	case arrow.BOOL, arrow.INT8, arrow.INT16, arrow.INT32, arrow.INT64,
+		arrow.UINT8, arrow.UINT16, arrow.UINT32, arrow.UINT64, arrow.FLOAT32, arrow.FLOAT64,
+		arrow.STRING, arrow.LARGE_STRING, arrow.DATE32, arrow.DATE64, arrow.TIMESTAMP,
+		arrow.DECIMAL128, arrow.DECIMAL256:
+		return true
+	}
+	return false
+}
+
+// column describes how one output column is derived from an input column.
+type column struct {
+	src  int
+	path []int // struct field path for flattened columns
+	enc  encoding
+}
+
+type encoding int
+
+const (
+	keep encoding = iota
+	asJSON
+	asString
+)
+
+// converter maps input records to the output schema of a writer.
+type converter struct {
+	schema *arrow.Schema
+	cols   []column
+	// identity is set when records pass through unchanged.
+	identity bool
+}
+
+func newConverter(in *arrow.Schema, nested Nested, native func(arrow.DataType) bool) *converter {
+	c := &converter{identity: true}
+	var fields []arrow.Field
+	var add func(f arrow.Field, src int, path []int)
+	add = func(f arrow.Field, src int, path []int) {
+		st, isStruct := f.Type.(*arrow.StructType)
+		switch {
+		case nested == NestedFlatten && isStruct:
+			c.identity = false
+			for i, sf := range st.Fields() {
+				sf.Name = f.Name + "." + sf.Name
+				sf.Nullable = true
+				add(sf, src, append(append([]int(nil), path...), i))
+			}
+			return
+		case isNested(f.Type) && nested != NestedDefault:
+			c.identity = false
+			fields = append(fields, arrow.Field{Name: f.Name, Type: arrow.BinaryTypes.String, Nullable: true})
+			c.cols = append(c.cols, column{src: src, path: path, enc: asJSON})
+		case !nativeTree(f.Type, native):
+			c.identity = false
+			enc := asString
+			if isNested(f.Type) {
+				enc = asJSON
+			}
+			fields = append(fields, arrow.Field{Name: f.Name, Type: arrow.BinaryTypes.String, Nullable: true})
+			c.cols = append(c.cols, column{src: src, path: path, enc: enc})
+		default:
+			if len(path) > 0 {
+				c.identity = false
+			}
+			fields = append(fields, f)
+			c.cols = append(c.cols, column{src: src, path: path, enc: keep})
+		}
+	}
+	for i, f := range in.Fields() {
+		add(f, i, nil)
+	}
+	if c.identity {
+		c.schema = in
+	} else {
+		c.schema = arrow.NewSchema(fields, nil)
+	}
+	return c
+}
+
+// nativeTree reports whether dt and every type nested in it is native.
+func nativeTree(dt arrow.DataType, native func(arrow.DataType) bool) bool {
+	if !native(dt) {
+		return false
+	}
+	switch t := dt.(type) {
+	case arrow.ListLikeType:
+		return nativeTree(t.Elem(), native)
+	case *arrow.StructType:
+		for _, f := range t.Fields() {
+			if !nativeTree(f.Type, native) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// convert returns rec in the output schema. The caller releases the returned record.
+func (c *converter) convert(rec arrow.RecordBatch) (arrow.RecordBatch, error) {
+	if c.identity {
+		rec.Retain()
+		return rec, nil
+	}
+	cols := make([]arrow.Array, len(c.cols))
+	defer func() {
+		for _, a := range cols {
+			if a != nil {
+				a.Release()
+			}
+		}
+	}()
+	for i, col := range c.cols {
+		arr := rec.Column(col.src)
+		for _, j := range col.path {
+			arr = arr.(*array.Struct).Field(j)
+		}
+		switch col.enc {
+		case keep:
+			arr.Retain()
+			cols[i] = arr
+		default:
+			enc, err := encodeStrings(arr, col.enc)
+			if err != nil {
+				return nil, fmt.Errorf("export: column %q: %w", c.schema.Field(i).Name, err)
+			}
+			cols[i] = enc
+		}
+	}
+	return array.NewRecordBatch(c.schema, cols, rec.NumRows()), nil
+}
+
+// encodeStrings renders arr as a string column, as JSON or with the Arrow value formatting.
+func encodeStrings(arr arrow.Array, enc encoding) (arrow.Array, error) {
+	b := array.NewStringBuilder(memory.DefaultAllocator)
+	defer b.Release()
+	b.Reserve(arr.Len())
+	for i := 0; i < arr.Len(); i++ {
+		if arr.IsNull(i) {
+			b.AppendNull()
+			continue
+		}
+		if enc == asString {
+			b.Append(arr.ValueStr(i))
+			continue
+		}
+		js, err := json.Marshal(arr.GetOneForMarshal(i))
+		if err != nil {
+			return nil, err
+		}
+		b.Append(string(js))
+	}
+	return b.NewArray(), nil
+}
+
+// csvWriter adapts csv.Writer, whose Close does not flush on its own.
+type csvWriter struct{ w *csv.Writer }
+
+func (c csvWriter) Write(rec arrow.RecordBatch) error { return c.w.Write(rec) }
+
+func (c csvWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+type ndjsonWriter struct {
+	w      *bufio.Writer
+	schema *arrow.Schema
+	// names holds the JSON-encoded column names, each followed by ':'.
+	names [][]byte
+	line  []byte
+}
+
+func newNDJSONWriter(w *bufio.Writer, sc *arrow.Schema) (*ndjsonWriter, error) {
+	names := make([][]byte, sc.NumFields())
+	for j, f := range sc.Fields() {
+		b, err := json.Marshal(f.Name)
+		if err != nil {
+			return nil, err
+		}
+		names[j] = append(b, ':')
+	}
+	return &ndjsonWriter{w: w, schema: sc, names: names}, nil
+}
+
+func (n *ndjsonWriter) Write(rec arrow.RecordBatch) error {
+	for i := 0; i < int(rec.NumRows()); i++ {
+		line := append(n.line[:0], '{')
+		for j := 0; j < int(rec.NumCols()); j++ {
+			if j > 0 {
+				line = append(line, ',')
+			}
+			line = append(line, n.names[j]...)
+			v, err := json.Marshal(rec.Column(j).GetOneForMarshal(i))
+			if err != nil {
+				return fmt.Errorf("export: column %q: %w", n.schema.Field(j).Name, err)
+			}
+			line = append(line, v...)
+		}
+		line = append(line, '}', '\n')
+		n.line = line
+		if _, err := n.w.Write(line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (n *ndjsonWriter) Close() error {
+	return n.w.Flush()
+}
@@ -0,0 +1,167 @@
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	ladybug "github.com/vkozio/ladybug-go-zero"
+)
+
+func nestedRecord(t *testing.T) arrow.RecordBatch {
+	t.Helper()
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "p", Type: arrow.StructOf(
+			arrow.Field{Name: "name", Type: arrow.BinaryTypes.String},
+			arrow.Field{Name: "tags", Type: arrow.ListOf(arrow.BinaryTypes.String)},
+		)},
+	}, nil)
+	b := array.NewRecordBuilder(memory.NewGoAllocator(), schema)
+	defer b.Release()
+	b.Field(0).(*array.Int64Builder).Append(1)
+	p := b.Field(1).(*array.StructBuilder)
+	p.Append(true)
+	p.FieldBuilder(0).(*array.StringBuilder).Append("ann")
+	tags := p.FieldBuilder(1).(*array.ListBuilder)
+	tags.Append(true)
+	tags.ValueBuilder().(*array.StringBuilder).Append("x")
+	return b.NewRecordBatch()
+}
+
+func TestConverter(t *testing.T) {
+	rec := nestedRecord(t)
+	defer rec.Release()
+
+	cases := []struct {
+		nested Nested
+		cols   string
+		values string
+	}{
+		{NestedDefault, "id,p", ""},
+		{NestedJSON, "id,p", `1,{"name":"ann","tags":["x"]}`},
+		{NestedFlatten, "id,p.name,p.tags", `1,ann,["x"]`},
+	}
+	for _, tc := range cases {
+		conv := newConverter(rec.Schema(), tc.nested, allNative)
+		var names []string
+		for _, f := range conv.schema.Fields() {
+			names = append(names, f.Name)
+		}
+		if got := strings.Join(names, ","); got != tc.cols {
+			t.Errorf("nested=%d: columns %s, want %s", tc.nested, got, tc.cols)
+		}
+		out, err := conv.convert(rec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tc.values != "" {
+			var vals []string
+			for j := 0; j < int(out.NumCols()); j++ {
+				vals = append(vals, out.Column(j).ValueStr(0))
+			}
+			if got := strings.Join(vals, ","); got != tc.values {
+				t.Errorf("nested=%d: values %s, want %s", tc.nested, got, tc.values)
+			}
+		}
+		out.Release()
+	}
+}
+
+type failWriter struct{}
+
+func (failWriter) Write([]byte) (int, error) { return 0, errors.New("disk full") }
+
+func TestNDJSONWriter(t *testing.T) {
+	rec := nestedRecord(t)
+	defer rec.Release()
+	var buf bytes.Buffer
+	bw := bufio.NewWriter(&buf)
+	nw, err := newNDJSONWriter(bw, rec.Schema())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for range 2 {
+		if err := nw.Write(rec); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := nw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	line := `{"id":1,"p":{"name":"ann","tags":["x"]}}` + "\n"
+	if got := buf.String(); got != line+line {
+		t.Errorf("NDJSON = %q", got)
+	}
+
+	// Write errors surface once the buffer fills instead of being dropped.
+	nw, err = newNDJSONWriter(bufio.NewWriterSize(failWriter{}, 16), rec.Schema())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := nw.Write(rec); err == nil {
+		t.Error("NDJSON write to failing writer succeeded")
+	}
+}
+
+func TestWriters(t *testing.T) {
+	ver, _ := ladybug.Version()
+	if ver == "" {
+		t.Skip("Ladybug libs not available; skipping")
+	}
+	ctx := context.Background()
+	db, err := ladybug.Open(ctx, filepath.Join(t.TempDir(), "testdb"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	const q = "UNWIND range(1, 3) AS x RETURN x, {a: x, b: [x, x]} AS s"
+	writers := map[string]func(*bytes.Buffer, *ladybug.Result) (int64, error){
+		"parquet": func(b *bytes.Buffer, r *ladybug.Result) (int64, error) { return WriteParquet(b, r, nil) },
+		"ipc":     func(b *bytes.Buffer, r *ladybug.Result) (int64, error) { return WriteArrowIPC(b, r, nil) },
+		"ipc-file": func(b *bytes.Buffer, r *ladybug.Result) (int64, error) {
+			return WriteArrowIPC(b, r, &Options{IPCFile: true})
+		},
+		"csv":    func(b *bytes.Buffer, r *ladybug.Result) (int64, error) { return WriteCSV(b, r, nil) },
+		"ndjson": func(b *bytes.Buffer, r *ladybug.Result) (int64, error) { return WriteNDJSON(b, r, nil) },
+	}
+	for name, write := range writers {
+		res, err := conn.Query(ctx, q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var buf bytes.Buffer
+		n, err := write(&buf, res)
+		res.Close()
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		if n != 3 || buf.Len() == 0 {
+			t.Errorf("%s: wrote %d rows, %d bytes", name, n, buf.Len())
+		}
+		switch name {
+		case "csv":
+			if lines := strings.Count(buf.String(), "\n"); lines != 4 {
+				t.Errorf("csv: %d lines, want header + 3:\n%s", lines, buf.String())
+			}
+		case "ndjson":
+			if !strings.HasPrefix(buf.String(), `{"x":1,"s":{"a":1,"b":[1,1]}}`) {
+				t.Errorf("ndjson:\n%s", buf.String())
+			}
+		}
+	}
+}
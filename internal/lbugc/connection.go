@@ -56,11 +56,12 @@ func (c *Connection) Query(cypher string) (*Result, error) {
 
 	st := C.lbug_connection_query(c.c, cQuery, out)
 	if st != C.LbugSuccess {
+		err := failedResult("query", st, out)
 		C.free(unsafe.Pointer(out))
-		return nil, errFromState("query", st, "")
+		return nil, err
 	}
 	res := &Result{c: out}
-	if err := resultErr(res.c); err != nil {
+	if err := resultErr("query", res.c); err != nil {
 		res.Close()
 		return nil, err
 	}
@@ -86,3 +87,28 @@ func (c *Connection) Interrupt() {
 	}
 	C.lbug_connection_interrupt(c.c)
 }
+
+// SetMaxNumThreadForExec sets the maximum number of threads used to execute queries.
+func (c *Connection) SetMaxNumThreadForExec(n uint64) error {
+	if c == nil || c.c == nil {
+		return errFromState("set_max_num_thread_for_exec", C.LbugError, "connection closed")
+	}
+	st := C.lbug_connection_set_max_num_thread_for_exec(c.c, C.uint64_t(n))
+	if st != C.LbugSuccess {
+		return errFromState("set_max_num_thread_for_exec", st, "")
+	}
+	return nil
+}
+
+// MaxNumThreadForExec returns the maximum number of threads used to execute queries.
+func (c *Connection) MaxNumThreadForExec() (uint64, error) {
+	if c == nil || c.c == nil {
+		return 0, errFromState("get_max_num_thread_for_exec", C.LbugError, "connection closed")
+	}
+	var out C.uint64_t
+	st := C.lbug_connection_get_max_num_thread_for_exec(c.c, &out)
+	if st != C.LbugSuccess {
+		return 0, errFromState("get_max_num_thread_for_exec", st, "")
+	}
+	return uint64(out), nil
+}
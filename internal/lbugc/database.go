@@ -3,6 +3,24 @@ package lbugc
 /*
 #include "lbug.h"
 #include <stdlib.h>
+
+static void set_thread_qos(lbug_system_config* c, uint32_t qos) {
+#if defined(__APPLE__)
+	c->thread_qos = qos;
+#else
+	(void)c;
+	(void)qos;
+#endif
+}
+
+static uint32_t get_thread_qos(lbug_system_config* c) {
+#if defined(__APPLE__)
+	return c->thread_qos;
+#else
+	(void)c;
+	return 0;
+#endif
+}
 */
 import "C"
 import "unsafe"
@@ -12,12 +30,54 @@ type Database struct {
 	c *C.lbug_database
 }
 
-// Open opens or creates a database at path using default system config.
+// SystemConfig mirrors lbug_system_config. Obtain defaults with DefaultSystemConfig
+// and override individual fields; a zero SystemConfig is not a valid configuration.
+type SystemConfig struct {
+	BufferPoolSize      uint64
+	MaxNumThreads       uint64
+	EnableCompression   bool
+	ReadOnly            bool
+	MaxDBSize           uint64
+	AutoCheckpoint      bool
+	CheckpointThreshold uint64
+	// ThreadQoS is only honoured on Apple platforms; ignored elsewhere.
+	ThreadQoS uint32
+}
+
+// DefaultSystemConfig returns the library defaults (lbug_default_system_config).
+func DefaultSystemConfig() SystemConfig {
+	c := C.lbug_default_system_config()
+	return SystemConfig{
+		BufferPoolSize:      uint64(c.buffer_pool_size),
+		MaxNumThreads:       uint64(c.max_num_threads),
+		EnableCompression:   bool(c.enable_compression),
+		ReadOnly:            bool(c.read_only),
+		MaxDBSize:           uint64(c.max_db_size),
+		AutoCheckpoint:      bool(c.auto_checkpoint),
+		CheckpointThreshold: uint64(c.checkpoint_threshold),
+		ThreadQoS:           uint32(C.get_thread_qos(&c)),
+	}
+}
+
+func (s SystemConfig) toC() C.lbug_system_config {
+	c := C.lbug_default_system_config()
+	c.buffer_pool_size = C.uint64_t(s.BufferPoolSize)
+	c.max_num_threads = C.uint64_t(s.MaxNumThreads)
+	c.enable_compression = C.bool(s.EnableCompression)
+	c.read_only = C.bool(s.ReadOnly)
+	c.max_db_size = C.uint64_t(s.MaxDBSize)
+	c.auto_checkpoint = C.bool(s.AutoCheckpoint)
+	c.checkpoint_threshold = C.uint64_t(s.CheckpointThreshold)
+	C.set_thread_qos(&c, C.uint32_t(s.ThreadQoS))
+	return c
+}
+
+// Open opens or creates a database at path using the given system config.
 // Caller must call Close on the returned Database.
-func Open(path string) (*Database, error) {
+func Open(path string, cfg SystemConfig) (*Database, error) {
 	cPath := C.CString(path)
 	defer C.free(unsafe.Pointer(cPath))
-	config := C.lbug_default_system_config()
+	config := cfg.toC()
 
 	out := (*C.lbug_database)(C.calloc(1, C.size_t(unsafe.Sizeof(C.lbug_database{}))))
 	if out == nil {
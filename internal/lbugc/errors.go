@@ -4,24 +4,49 @@ package lbugc
 #include "lbug.h"
 */
 import "C"
-import "fmt"
+
+// Error is a failure reported by (or on the way to) the C API.
+// Op names the failing operation; Message is the library's error message, if any.
+type Error struct {
+	Op      string
+	Message string
+}
+
+func (e *Error) Error() string {
+	if e.Message != "" {
+		return "ladybug: " + e.Op + ": " + e.Message
+	}
+	return "ladybug: " + e.Op + " failed"
+}
 
 func errFromState(op string, st C.lbug_state, msg string) error {
 	if st == C.LbugSuccess {
 		return nil
 	}
-	if msg != "" {
-		return fmt.Errorf("ladybug: %s: %s", op, msg)
-	}
-	return fmt.Errorf("ladybug: %s failed", op)
+	return &Error{Op: op, Message: msg}
 }
 
 // resultErr returns an error if the query result indicates failure.
 // Caller must not use res after this if error is non-nil for message extraction.
-func resultErr(res *C.lbug_query_result) error {
+func resultErr(op string, res *C.lbug_query_result) error {
 	if res == nil || C.lbug_query_result_is_success(res) {
 		return nil
 	}
 	msg := copyCString(C.lbug_query_result_get_error_message(res))
-	return errFromState("query", C.LbugError, msg)
+	return errFromState(op, C.LbugError, msg)
+}
+
+// failedResult builds the error for a query/execute call that returned st != LbugSuccess.
+// The library may still have populated out with a failed result carrying the error message;
+// if so the result is destroyed. The caller frees the out allocation.
+func failedResult(op string, st C.lbug_state, out *C.lbug_query_result) error {
+	if out._query_result == nil {
+		return errFromState(op, st, "")
+	}
+	err := resultErr(op, out)
+	C.lbug_query_result_destroy(out)
+	if err == nil {
+		err = errFromState(op, st, "")
+	}
+	return err
 }
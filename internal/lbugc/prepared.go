@@ -30,8 +30,13 @@ func (c *Connection) Prepare(cypher string) (*PreparedStatement, error) {
 
 	st := C.lbug_connection_prepare(c.c, cQuery, out)
 	if st != C.LbugSuccess {
+		var msg string
+		if out._prepared_statement != nil {
+			msg = copyCString(C.lbug_prepared_statement_get_error_message(out))
+			C.lbug_prepared_statement_destroy(out)
+		}
 		C.free(unsafe.Pointer(out))
-		return nil, errFromState("prepare", st, "")
+		return nil, errFromState("prepare", st, msg)
 	}
 	ps := &PreparedStatement{c: out}
 	if !bool(C.lbug_prepared_statement_is_success(ps.c)) {
@@ -106,18 +111,18 @@ func (ps *PreparedStatement) BindString(name string, v string) error {
 	return nil
 }
 
-// BindDate binds a date parameter using days since Unix epoch at midnight UTC.
-func (ps *PreparedStatement) BindDate(name string, v time.Time) error {
+// BindDate binds a date parameter for a calendar day, converted with lbug_date_from_tm.
+func (ps *PreparedStatement) BindDate(name string, year, month, day int) error {
 	if ps == nil || ps.c == nil {
 		return errFromState("bind_date", C.LbugError, "prepared statement closed")
 	}
 	cName := C.CString(name)
 	defer C.free(unsafe.Pointer(cName))
 
-	t := v.UTC()
-	days := t.Unix() / 86400
-	var date C.lbug_date_t
-	date.days = C.int32_t(days)
+	date, err := dateFromTm(year, month, day)
+	if err != nil {
+		return err
+	}
 	if C.lbug_prepared_statement_bind_date(ps.c, cName, date) != C.LbugSuccess {
 		return errFromState("bind_date", C.LbugError, "")
 	}
@@ -142,17 +147,18 @@ func (ps *PreparedStatement) BindTime(name string, v time.Time) error {
 	return nil
 }
 
-// BindInterval binds an interval parameter using the total duration.
-func (ps *PreparedStatement) BindInterval(name string, v time.Duration) error {
+// BindInterval binds an interval parameter.
+func (ps *PreparedStatement) BindInterval(name string, months, days int32, micros int64) error {
 	if ps == nil || ps.c == nil {
 		return errFromState("bind_interval", C.LbugError, "prepared statement closed")
 	}
 	cName := C.CString(name)
 	defer C.free(unsafe.Pointer(cName))
 
-	seconds := float64(v) / float64(time.Second)
 	var interval C.lbug_interval_t
-	C.lbug_interval_from_difftime(C.double(seconds), &interval)
+	interval.months = C.int32_t(months)
+	interval.days = C.int32_t(days)
+	interval.micros = C.int64_t(micros)
 	if C.lbug_prepared_statement_bind_interval(ps.c, cName, interval) != C.LbugSuccess {
 		return errFromState("bind_interval", C.LbugError, "")
 	}
@@ -190,11 +196,12 @@ func (ps *PreparedStatement) Execute(conn *Connection) (*Result, error) {
 
 	st := C.lbug_connection_execute(conn.c, ps.c, out)
 	if st != C.LbugSuccess {
+		err := failedResult("execute", st, out)
 		C.free(unsafe.Pointer(out))
-		return nil, errFromState("execute", st, "")
+		return nil, err
 	}
 	res := &Result{c: out}
-	if err := resultErr(res.c); err != nil {
+	if err := resultErr("execute", res.c); err != nil {
 		res.Close()
 		return nil, err
 	}
@@ -59,6 +59,28 @@ func (r *Result) ColumnName(index uint64) string {
 	return copyCString(cName)
 }
 
+// ColumnType returns the data type id (lbug_data_type_id) of the column at index and,
+// for ARRAY columns, the fixed number of elements (0 otherwise).
+func (r *Result) ColumnType(index uint64) (id int, arrayLen uint64, err error) {
+	if r == nil || r.c == nil {
+		return 0, 0, errFromState("get_column_data_type", C.LbugError, "result closed")
+	}
+	var dt C.lbug_logical_type
+	st := C.lbug_query_result_get_column_data_type(r.c, C.uint64_t(index), &dt)
+	if st != C.LbugSuccess {
+		return 0, 0, errFromState("get_column_data_type", st, "")
+	}
+	defer C.lbug_data_type_destroy(&dt)
+	typeID := C.lbug_data_type_get_id(&dt)
+	if typeID == C.LBUG_ARRAY {
+		var n C.uint64_t
+		if C.lbug_data_type_get_num_elements_in_array(&dt, &n) == C.LbugSuccess {
+			arrayLen = uint64(n)
+		}
+	}
+	return int(typeID), arrayLen, nil
+}
+
 // HasNext returns true if there is another row.
 func (r *Result) HasNext() bool {
 	if r == nil || r.c == nil {
@@ -89,6 +111,41 @@ func (r *Result) GetNext() (row *Row, ok bool, err error) {
 	return &Row{c: ft, numCols: r.NumColumns()}, true, nil
 }
 
+// HasNextResult returns true if a multi-statement query has another statement result.
+func (r *Result) HasNextResult() bool {
+	if r == nil || r.c == nil {
+		return false
+	}
+	return bool(C.lbug_query_result_has_next_query_result(r.c))
+}
+
+// NextResult returns the result of the next statement, or nil when there is none.
+// The returned Result is owned by the C result it was obtained from: it must be closed
+// before (and never after) the head Result of the chain.
+func (r *Result) NextResult() (*Result, error) {
+	if r == nil || r.c == nil {
+		return nil, errFromState("get_next_query_result", C.LbugError, "result closed")
+	}
+	if !bool(C.lbug_query_result_has_next_query_result(r.c)) {
+		return nil, nil
+	}
+	out := (*C.lbug_query_result)(C.calloc(1, C.size_t(unsafe.Sizeof(C.lbug_query_result{}))))
+	if out == nil {
+		return nil, errFromState("get_next_query_result", C.LbugError, "alloc failed")
+	}
+	st := C.lbug_query_result_get_next_query_result(r.c, out)
+	if st != C.LbugSuccess {
+		C.free(unsafe.Pointer(out))
+		return nil, errFromState("get_next_query_result", st, "")
+	}
+	next := &Result{c: out}
+	if err := resultErr("get_next_query_result", next.c); err != nil {
+		next.Close()
+		return nil, err
+	}
+	return next, nil
+}
+
 // SchemaRaw returns the Arrow schema as an unsafe.Pointer to C ArrowSchema (Arrow C Data Interface).
 // The caller must call the returned cleanup callback to free the ArrowSchema struct.
 // If the caller does not pass the schema into arrow/cdata (which calls ArrowSchemaRelease),
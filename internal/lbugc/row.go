@@ -6,6 +6,7 @@ package lbugc
 */
 import "C"
 import (
+	"math/big"
 	"time"
 	"unsafe"
 )
@@ -41,6 +42,54 @@ func (row *Row) Value(index uint64) (interface{}, error) {
 	return valueToGo(&v)
 }
 
+// InternalID is the identity of a node or rel: the table it belongs to and its offset there.
+type InternalID struct {
+	Table, Offset uint64
+}
+
+// Interval is an INTERVAL value in the library's representation.
+type Interval struct {
+	Months, Days int32
+	Micros       int64
+}
+
+// Decimal is the text of a DECIMAL value, as rendered by the library.
+type Decimal string
+
+// Date is a DATE value: days since 1970-01-01.
+type Date int32
+
+// TimestampUnit is the storage type of a timestamp value.
+type TimestampUnit uint8
+
+// Timestamp units. Ordered like the root package's TimestampPrecision.
+const (
+	TimestampMicros TimestampUnit = iota // TIMESTAMP
+	TimestampSec                         // TIMESTAMP_SEC
+	TimestampMillis                      // TIMESTAMP_MS
+	TimestampNanos                       // TIMESTAMP_NS
+	TimestampTZ                          // TIMESTAMP_TZ, microseconds in UTC
+)
+
+// Timestamp is a timestamp value: Value units since the Unix epoch.
+type Timestamp struct {
+	Value int64
+	Unit  TimestampUnit
+}
+
+// Time returns ts as a time.Time in UTC.
+func (ts Timestamp) Time() time.Time {
+	switch ts.Unit {
+	case TimestampSec:
+		return time.Unix(ts.Value, 0).UTC()
+	case TimestampMillis:
+		return time.UnixMilli(ts.Value).UTC()
+	case TimestampNanos:
+		return time.Unix(0, ts.Value).UTC()
+	}
+	return time.UnixMicro(ts.Value).UTC()
+}
+
 func valueToGo(v *C.lbug_value) (interface{}, error) {
 	if C.lbug_value_is_null(v) {
 		return nil, nil
@@ -68,6 +117,18 @@ func valueToGo(v *C.lbug_value) (interface{}, error) {
 			return copyCString(C.lbug_value_to_string(v)), nil
 		}
 		return uint64(out), nil
+	case C.LBUG_INT128:
+		var out C.lbug_int128_t
+		if C.lbug_value_get_int128(v, &out) != C.LbugSuccess {
+			return copyCString(C.lbug_value_to_string(v)), nil
+		}
+		return int128ToBig(out), nil
+	case C.LBUG_DECIMAL:
+		var out *C.char
+		if C.lbug_value_get_decimal_as_string(v, &out) != C.LbugSuccess {
+			return copyCString(C.lbug_value_to_string(v)), nil
+		}
+		return Decimal(copyCString(out)), nil
 	case C.LBUG_FLOAT:
 		var out C.float
 		if C.lbug_value_get_float(v, &out) != C.LbugSuccess {
@@ -85,53 +146,43 @@ func valueToGo(v *C.lbug_value) (interface{}, error) {
 		if C.lbug_value_get_date(v, &out) != C.LbugSuccess {
 			return copyCString(C.lbug_value_to_string(v)), nil
 		}
-		days := int64(out.days)
-		// Days since Unix epoch, map to midnight UTC.
-		return time.Unix(days*86400, 0).UTC(), nil
+		return Date(out.days), nil
 	case C.LBUG_TIMESTAMP:
 		var out C.lbug_timestamp_t
 		if C.lbug_value_get_timestamp(v, &out) != C.LbugSuccess {
 			return copyCString(C.lbug_value_to_string(v)), nil
 		}
-		micros := int64(out.value)
-		return time.Unix(0, micros*int64(time.Microsecond)).UTC(), nil
+		return Timestamp{Value: int64(out.value), Unit: TimestampMicros}, nil
 	case C.LBUG_TIMESTAMP_NS:
 		var out C.lbug_timestamp_ns_t
 		if C.lbug_value_get_timestamp_ns(v, &out) != C.LbugSuccess {
 			return copyCString(C.lbug_value_to_string(v)), nil
 		}
-		return time.Unix(0, int64(out.value)).UTC(), nil
+		return Timestamp{Value: int64(out.value), Unit: TimestampNanos}, nil
 	case C.LBUG_TIMESTAMP_MS:
 		var out C.lbug_timestamp_ms_t
 		if C.lbug_value_get_timestamp_ms(v, &out) != C.LbugSuccess {
 			return copyCString(C.lbug_value_to_string(v)), nil
 		}
-		ms := int64(out.value)
-		return time.Unix(0, ms*int64(time.Millisecond)).UTC(), nil
+		return Timestamp{Value: int64(out.value), Unit: TimestampMillis}, nil
 	case C.LBUG_TIMESTAMP_SEC:
 		var out C.lbug_timestamp_sec_t
 		if C.lbug_value_get_timestamp_sec(v, &out) != C.LbugSuccess {
 			return copyCString(C.lbug_value_to_string(v)), nil
 		}
-		sec := int64(out.value)
-		return time.Unix(sec, 0).UTC(), nil
+		return Timestamp{Value: int64(out.value), Unit: TimestampSec}, nil
 	case C.LBUG_TIMESTAMP_TZ:
 		var out C.lbug_timestamp_tz_t
 		if C.lbug_value_get_timestamp_tz(v, &out) != C.LbugSuccess {
 			return copyCString(C.lbug_value_to_string(v)), nil
 		}
-		micros := int64(out.value)
-		// Treat stored microseconds as UTC.
-		return time.Unix(0, micros*int64(time.Microsecond)).UTC(), nil
+		return Timestamp{Value: int64(out.value), Unit: TimestampTZ}, nil
 	case C.LBUG_INTERVAL:
 		var out C.lbug_interval_t
 		if C.lbug_value_get_interval(v, &out) != C.LbugSuccess {
 			return copyCString(C.lbug_value_to_string(v)), nil
 		}
-		var seconds C.double
-		C.lbug_interval_to_difftime(out, &seconds)
-		d := time.Duration(float64(seconds) * float64(time.Second))
-		return d, nil
+		return Interval{Months: int32(out.months), Days: int32(out.days), Micros: int64(out.micros)}, nil
 	case C.LBUG_STRING:
 		var out *C.char
 		if C.lbug_value_get_string(v, &out) != C.LbugSuccess {
@@ -151,10 +202,18 @@ func valueToGo(v *C.lbug_value) (interface{}, error) {
 			return copyCString(C.lbug_value_to_string(v)), nil
 		}
 		return copyCString(out), nil
+	case C.LBUG_INTERNAL_ID:
+		var out C.lbug_internal_id_t
+		if C.lbug_value_get_internal_id(v, &out) != C.LbugSuccess {
+			return copyCString(C.lbug_value_to_string(v)), nil
+		}
+		return InternalID{Table: uint64(out.table_id), Offset: uint64(out.offset)}, nil
 	case C.LBUG_LIST, C.LBUG_ARRAY:
 		return listToSlice(v)
-	case C.LBUG_STRUCT, C.LBUG_RECURSIVE_REL, C.LBUG_MAP, C.LBUG_UNION:
+	case C.LBUG_STRUCT, C.LBUG_MAP, C.LBUG_UNION:
 		return structOrMapToGo(v)
+	case C.LBUG_RECURSIVE_REL:
+		return recursiveRelToMap(v)
 	case C.LBUG_NODE:
 		return nodeToMap(v)
 	case C.LBUG_REL:
@@ -195,7 +254,7 @@ func listToSlice(v *C.lbug_value) (interface{}, error) {
 	return out, nil
 }
 
-// structOrMapToGo returns map[string]interface{} for STRUCT/NODE/REL/RECURSIVE_REL/MAP/UNION values,
+// structOrMapToGo returns map[string]interface{} for STRUCT/MAP/UNION values,
 // or a string fallback on error.
 func structOrMapToGo(v *C.lbug_value) (interface{}, error) {
 	// MAP has dedicated accessors; handle it first.
@@ -406,3 +465,39 @@ func relToMap(v *C.lbug_value) (interface{}, error) {
 	m["properties"] = props
 	return m, nil
 }
+
+// recursiveRelToMap returns a generic map representation of a RECURSIVE_REL value.
+// Keys: "nodes" ([]interface{} of node maps), "rels" ([]interface{} of rel maps).
+func recursiveRelToMap(v *C.lbug_value) (interface{}, error) {
+	var nodesVal C.lbug_value
+	if C.lbug_value_get_recursive_rel_node_list(v, &nodesVal) != C.LbugSuccess {
+		return structOrMapToGo(v)
+	}
+	nodes, err := listToSlice(&nodesVal)
+	C.lbug_value_destroy(&nodesVal)
+	if err != nil {
+		return nil, err
+	}
+
+	var relsVal C.lbug_value
+	if C.lbug_value_get_recursive_rel_rel_list(v, &relsVal) != C.LbugSuccess {
+		return structOrMapToGo(v)
+	}
+	rels, err := listToSlice(&relsVal)
+	C.lbug_value_destroy(&relsVal)
+	if err != nil {
+		return nil, err
+	}
+
+	m := make(map[string]interface{}, 2)
+	m["nodes"] = nodes
+	m["rels"] = rels
+	return m, nil
+}
+
+// int128ToBig converts a two's complement int128 into a big.Int.
+func int128ToBig(v C.lbug_int128_t) *big.Int {
+	b := big.NewInt(int64(v.high))
+	b.Lsh(b, 64)
+	return b.Add(b, new(big.Int).SetUint64(uint64(v.low)))
+}
@@ -0,0 +1,279 @@
+package lbugc
+
+/*
+#include "lbug.h"
+#include <stdlib.h>
+*/
+import "C"
+import (
+	"math/big"
+	"unsafe"
+)
+
+// Value wraps a C lbug_value created on the Go side (for binding). Call Destroy when done.
+// Composite constructors copy their inputs, so children may be destroyed right after use.
+type Value struct {
+	c *C.lbug_value
+}
+
+func newValue(c *C.lbug_value, op string) (*Value, error) {
+	if c == nil {
+		return nil, errFromState(op, C.LbugError, "alloc failed")
+	}
+	return &Value{c: c}, nil
+}
+
+// Destroy frees the value.
+func (v *Value) Destroy() {
+	if v == nil || v.c == nil {
+		return
+	}
+	C.lbug_value_destroy(v.c)
+	v.c = nil
+}
+
+// NewNull creates a NULL value of the given scalar type id (lbug_data_type_id); 0 (ANY) is untyped.
+func NewNull(typeID int) (*Value, error) {
+	if typeID == int(C.LBUG_ANY) {
+		return newValue(C.lbug_value_create_null(), "value_create_null")
+	}
+	var dt C.lbug_logical_type
+	C.lbug_data_type_create(C.lbug_data_type_id(typeID), nil, 0, &dt)
+	defer C.lbug_data_type_destroy(&dt)
+	return newValue(C.lbug_value_create_null_with_data_type(&dt), "value_create_null")
+}
+
+// NewBool creates a BOOL value.
+func NewBool(b bool) (*Value, error) {
+	return newValue(C.lbug_value_create_bool(C.bool(b)), "value_create_bool")
+}
+
+// NewInt8 creates an INT8 value.
+func NewInt8(i int8) (*Value, error) {
+	return newValue(C.lbug_value_create_int8(C.int8_t(i)), "value_create_int8")
+}
+
+// NewInt16 creates an INT16 value.
+func NewInt16(i int16) (*Value, error) {
+	return newValue(C.lbug_value_create_int16(C.int16_t(i)), "value_create_int16")
+}
+
+// NewInt32 creates an INT32 value.
+func NewInt32(i int32) (*Value, error) {
+	return newValue(C.lbug_value_create_int32(C.int32_t(i)), "value_create_int32")
+}
+
+// NewInt64 creates an INT64 value.
+func NewInt64(i int64) (*Value, error) {
+	return newValue(C.lbug_value_create_int64(C.int64_t(i)), "value_create_int64")
+}
+
+// NewUInt8 creates a UINT8 value.
+func NewUInt8(u uint8) (*Value, error) {
+	return newValue(C.lbug_value_create_uint8(C.uint8_t(u)), "value_create_uint8")
+}
+
+// NewUInt16 creates a UINT16 value.
+func NewUInt16(u uint16) (*Value, error) {
+	return newValue(C.lbug_value_create_uint16(C.uint16_t(u)), "value_create_uint16")
+}
+
+// NewUInt32 creates a UINT32 value.
+func NewUInt32(u uint32) (*Value, error) {
+	return newValue(C.lbug_value_create_uint32(C.uint32_t(u)), "value_create_uint32")
+}
+
+// NewUInt64 creates a UINT64 value.
+func NewUInt64(u uint64) (*Value, error) {
+	return newValue(C.lbug_value_create_uint64(C.uint64_t(u)), "value_create_uint64")
+}
+
+// NewFloat creates a FLOAT value.
+func NewFloat(f float32) (*Value, error) {
+	return newValue(C.lbug_value_create_float(C.float(f)), "value_create_float")
+}
+
+// NewDouble creates a DOUBLE value.
+func NewDouble(f float64) (*Value, error) {
+	return newValue(C.lbug_value_create_double(C.double(f)), "value_create_double")
+}
+
+// NewString creates a STRING value.
+func NewString(s string) (*Value, error) {
+	cs := C.CString(s)
+	defer C.free(unsafe.Pointer(cs))
+	return newValue(C.lbug_value_create_string(cs), "value_create_string")
+}
+
+// NewTimestampNS creates a TIMESTAMP_NS value from nanoseconds since the Unix epoch.
+func NewTimestampNS(ns int64) (*Value, error) {
+	var ts C.lbug_timestamp_ns_t
+	ts.value = C.int64_t(ns)
+	return newValue(C.lbug_value_create_timestamp_ns(ts), "value_create_timestamp_ns")
+}
+
+// NewDate creates a DATE value for a calendar day, converted with lbug_date_from_tm.
+func NewDate(year, month, day int) (*Value, error) {
+	date, err := dateFromTm(year, month, day)
+	if err != nil {
+		return nil, err
+	}
+	return newValue(C.lbug_value_create_date(date), "value_create_date")
+}
+
+// dateFromTm converts a calendar day to lbug_date_t.
+func dateFromTm(year, month, day int) (C.lbug_date_t, error) {
+	var tm C.struct_tm
+	tm.tm_year = C.int(year - 1900)
+	tm.tm_mon = C.int(month - 1)
+	tm.tm_mday = C.int(day)
+	var date C.lbug_date_t
+	if st := C.lbug_date_from_tm(tm, &date); st != C.LbugSuccess {
+		return date, errFromState("date_from_tm", st, "")
+	}
+	return date, nil
+}
+
+// NewTimestamp creates a timestamp value of the given unit.
+func NewTimestamp(ts Timestamp) (*Value, error) {
+	switch ts.Unit {
+	case TimestampSec:
+		var v C.lbug_timestamp_sec_t
+		v.value = C.int64_t(ts.Value)
+		return newValue(C.lbug_value_create_timestamp_sec(v), "value_create_timestamp_sec")
+	case TimestampMillis:
+		var v C.lbug_timestamp_ms_t
+		v.value = C.int64_t(ts.Value)
+		return newValue(C.lbug_value_create_timestamp_ms(v), "value_create_timestamp_ms")
+	case TimestampNanos:
+		return NewTimestampNS(ts.Value)
+	case TimestampTZ:
+		var v C.lbug_timestamp_tz_t
+		v.value = C.int64_t(ts.Value)
+		return newValue(C.lbug_value_create_timestamp_tz(v), "value_create_timestamp_tz")
+	}
+	var v C.lbug_timestamp_t
+	v.value = C.int64_t(ts.Value)
+	return newValue(C.lbug_value_create_timestamp(v), "value_create_timestamp")
+}
+
+// NewInterval creates an INTERVAL value.
+func NewInterval(months, days int32, micros int64) (*Value, error) {
+	var iv C.lbug_interval_t
+	iv.months = C.int32_t(months)
+	iv.days = C.int32_t(days)
+	iv.micros = C.int64_t(micros)
+	return newValue(C.lbug_value_create_interval(iv), "value_create_interval")
+}
+
+// NewInt128 creates an INT128 value. i must fit in 128 bits as a signed integer.
+func NewInt128(i *big.Int) (*Value, error) {
+	var lo big.Int
+	lo.And(i, new(big.Int).SetUint64(^uint64(0)))
+	hi := new(big.Int).Rsh(i, 64) // arithmetic shift: floor division for negative i
+	var v C.lbug_int128_t
+	v.low = C.uint64_t(lo.Uint64())
+	v.high = C.int64_t(hi.Int64())
+	return newValue(C.lbug_value_create_int128(v), "value_create_int128")
+}
+
+// NewInternalID creates an INTERNAL_ID value.
+func NewInternalID(table, offset uint64) (*Value, error) {
+	var id C.lbug_internal_id_t
+	id.table_id = C.uint64_t(table)
+	id.offset = C.uint64_t(offset)
+	return newValue(C.lbug_value_create_internal_id(id), "value_create_internal_id")
+}
+
+// NewEmptyList creates an empty LIST whose elements have the given scalar type id.
+func NewEmptyList(elemTypeID int) (*Value, error) {
+	var elem, list C.lbug_logical_type
+	C.lbug_data_type_create(C.lbug_data_type_id(elemTypeID), nil, 0, &elem)
+	defer C.lbug_data_type_destroy(&elem)
+	C.lbug_data_type_create(C.LBUG_LIST, &elem, 0, &list)
+	defer C.lbug_data_type_destroy(&list)
+	return newValue(C.lbug_value_create_default(&list), "value_create_list")
+}
+
+// NewList creates a LIST from elems, which must all have the same type and be non-empty.
+func NewList(elems []*Value) (*Value, error) {
+	if len(elems) == 0 {
+		return nil, errFromState("value_create_list", C.LbugError, "empty list")
+	}
+	arr := valuePtrArray(elems)
+	defer C.free(unsafe.Pointer(arr))
+	var out *C.lbug_value
+	if st := C.lbug_value_create_list(C.uint64_t(len(elems)), arr, &out); st != C.LbugSuccess {
+		return nil, errFromState("value_create_list", st, "list elements must have the same type")
+	}
+	return newValue(out, "value_create_list")
+}
+
+// NewStruct creates a STRUCT with the given unique field names and values.
+func NewStruct(names []string, values []*Value) (*Value, error) {
+	if len(names) != len(values) || len(names) == 0 {
+		return nil, errFromState("value_create_struct", C.LbugError, "struct needs at least one field")
+	}
+	cNames := (**C.char)(C.calloc(C.size_t(len(names)), C.size_t(unsafe.Sizeof((*C.char)(nil)))))
+	nameSlice := unsafe.Slice(cNames, len(names))
+	defer func() {
+		for _, p := range nameSlice {
+			C.free(unsafe.Pointer(p))
+		}
+		C.free(unsafe.Pointer(cNames))
+	}()
+	for i, n := range names {
+		nameSlice[i] = C.CString(n)
+	}
+	arr := valuePtrArray(values)
+	defer C.free(unsafe.Pointer(arr))
+	var out *C.lbug_value
+	if st := C.lbug_value_create_struct(C.uint64_t(len(names)), cNames, arr, &out); st != C.LbugSuccess {
+		return nil, errFromState("value_create_struct", st, "")
+	}
+	return newValue(out, "value_create_struct")
+}
+
+// NewMap creates a MAP from parallel keys and values; keys must be unique and share a type,
+// as must values.
+func NewMap(keys, values []*Value) (*Value, error) {
+	if len(keys) != len(values) || len(keys) == 0 {
+		return nil, errFromState("value_create_map", C.LbugError, "map needs at least one entry")
+	}
+	ks := valuePtrArray(keys)
+	defer C.free(unsafe.Pointer(ks))
+	vs := valuePtrArray(values)
+	defer C.free(unsafe.Pointer(vs))
+	var out *C.lbug_value
+	if st := C.lbug_value_create_map(C.uint64_t(len(keys)), ks, vs, &out); st != C.LbugSuccess {
+		return nil, errFromState("value_create_map", st, "map keys and values must each have one type")
+	}
+	return newValue(out, "value_create_map")
+}
+
+// valuePtrArray copies the C pointers of vs into a C array (Go memory must not hold Go
+// pointers passed to C). Caller frees the array.
+func valuePtrArray(vs []*Value) **C.lbug_value {
+	arr := (**C.lbug_value)(C.calloc(C.size_t(len(vs)), C.size_t(unsafe.Sizeof((*C.lbug_value)(nil)))))
+	s := unsafe.Slice(arr, len(vs))
+	for i, v := range vs {
+		s[i] = v.c
+	}
+	return arr
+}
+
+// BindValue binds v to the named parameter. The statement copies v; the caller still destroys it.
+func (ps *PreparedStatement) BindValue(name string, v *Value) error {
+	if ps == nil || ps.c == nil {
+		return errFromState("bind_value", C.LbugError, "prepared statement closed")
+	}
+	if v == nil || v.c == nil {
+		return errFromState("bind_value", C.LbugError, "nil value")
+	}
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	if C.lbug_prepared_statement_bind_value(ps.c, cName, v.c) != C.LbugSuccess {
+		return errFromState("bind_value", C.LbugError, "")
+	}
+	return nil
+}
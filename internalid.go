@@ -0,0 +1,85 @@
+package ladybug
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// InternalID identifies a node or relationship: the id of its table and its offset in that
+// table. It is comparable, so it can key Go maps, and formats as "table:offset" like
+// Ladybug's own rendering of INTERNAL_ID values. It is what Node.ID, Rel.ID, Rel.SrcID,
+// Rel.DstID and id() return, and binds back as an INTERNAL_ID parameter.
+type InternalID struct {
+	Table  uint64
+	Offset uint64
+}
+
+// String returns the ID as "table:offset".
+func (id InternalID) String() string {
+	return strconv.FormatUint(id.Table, 10) + ":" + strconv.FormatUint(id.Offset, 10)
+}
+
+// ParseInternalID parses an ID in the "table:offset" form produced by String.
+func ParseInternalID(s string) (InternalID, error) {
+	table, offset, ok := strings.Cut(s, ":")
+	if !ok {
+		return InternalID{}, fmt.Errorf("ladybug: invalid internal id %q", s)
+	}
+	t, err := strconv.ParseUint(table, 10, 64)
+	if err != nil {
+		return InternalID{}, fmt.Errorf("ladybug: invalid internal id %q", s)
+	}
+	o, err := strconv.ParseUint(offset, 10, 64)
+	if err != nil {
+		return InternalID{}, fmt.Errorf("ladybug: invalid internal id %q", s)
+	}
+	return InternalID{Table: t, Offset: o}, nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (id InternalID) MarshalText() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (id *InternalID) UnmarshalText(b []byte) error {
+	v, err := ParseInternalID(string(b))
+	if err != nil {
+		return err
+	}
+	*id = v
+	return nil
+}
+
+// asInternalID converts the representations an ID may have in a driver value: an
+// InternalID, a "table:offset" string, or the {offset, table} struct of the Arrow layout.
+func asInternalID(v any) (InternalID, bool) {
+	switch id := v.(type) {
+	case InternalID:
+		return id, true
+	case string:
+		parsed, err := ParseInternalID(id)
+		return parsed, err == nil
+	case map[string]any:
+		table, ok1 := id["table"]
+		offset, ok2 := id["offset"]
+		if !ok1 || !ok2 || len(id) != 2 {
+			return InternalID{}, false
+		}
+		t, ok1 := asUint64(table)
+		o, ok2 := asUint64(offset)
+		return InternalID{Table: t, Offset: o}, ok1 && ok2
+	}
+	return InternalID{}, false
+}
+
+func asUint64(v any) (uint64, bool) {
+	switch n := v.(type) {
+	case int64:
+		return uint64(n), n >= 0
+	case uint64:
+		return n, true
+	}
+	return 0, false
+}
@@ -0,0 +1,125 @@
+package ladybug
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/vkozio/ladybug-go-zero/internal/lbugc"
+)
+
+func TestInternalID(t *testing.T) {
+	id := InternalID{Table: 3, Offset: 12}
+	if s := id.String(); s != "3:12" {
+		t.Errorf("String = %q, want 3:12", s)
+	}
+	got, err := ParseInternalID("3:12")
+	if err != nil || got != id {
+		t.Errorf("ParseInternalID = %v, %v; want %v", got, err, id)
+	}
+	for _, bad := range []string{"", "3", "3:", ":1", "a:1", "1:-2"} {
+		if _, err := ParseInternalID(bad); err == nil {
+			t.Errorf("ParseInternalID(%q) succeeded", bad)
+		}
+	}
+	b, err := json.Marshal(map[InternalID]int{id: 1})
+	if err != nil || string(b) != `{"3:12":1}` {
+		t.Errorf("json = %s, %v", b, err)
+	}
+	var back map[InternalID]int
+	if err := json.Unmarshal(b, &back); err != nil || back[id] != 1 {
+		t.Errorf("json round trip = %v, %v", back, err)
+	}
+
+	for _, v := range []any{id, "3:12", map[string]any{"offset": int64(12), "table": int64(3)}} {
+		if got, ok := asInternalID(v); !ok || got != id {
+			t.Errorf("asInternalID(%v) = %v, %v", v, got, ok)
+		}
+	}
+	v := fromDriver(map[string]any{
+		"id":     lbugc.InternalID{Table: 3, Offset: 12},
+		"labels": []any{"Person"},
+	}, time.UTC, false)
+	n, _ := AsNode(v)
+	if n.ID != id {
+		t.Errorf("Node.ID = %v, want %v", n.ID, id)
+	}
+	r, _ := AsRel(graphValue(map[string]any{
+		"_ID":    map[string]any{"offset": int64(0), "table": int64(5)},
+		"_LABEL": "Knows",
+		"_SRC":   map[string]any{"offset": int64(12), "table": int64(3)},
+		"_DST":   map[string]any{"offset": int64(13), "table": int64(3)},
+	}))
+	if r.SrcID != id || r.DstID != (InternalID{Table: 3, Offset: 13}) || r.ID != (InternalID{Table: 5}) {
+		t.Errorf("Rel ids = %v %v %v", r.ID, r.SrcID, r.DstID)
+	}
+}
+
+func TestInternalIDRoundTrip(t *testing.T) {
+	ver, _ := Version()
+	if ver == "" {
+		t.Skip("Ladybug libs not available; skipping")
+	}
+	ctx := context.Background()
+	db, err := Open(ctx, filepath.Join(t.TempDir(), "testdb"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	for _, q := range []string{
+		"CREATE NODE TABLE Person(name STRING PRIMARY KEY)",
+		"CREATE REL TABLE Knows(FROM Person TO Person)",
+		"CREATE (:Person {name: 'a'})-[:Knows]->(:Person {name: 'b'})",
+	} {
+		res, err := conn.Query(ctx, q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Close()
+	}
+	rels, err := QueryAll[Rel](ctx, conn, "MATCH ()-[k:Knows]->() RETURN k", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rels) != 1 {
+		t.Fatalf("rels = %v", rels)
+	}
+	nodes := map[InternalID]string{}
+	res, err := conn.Query(ctx, "MATCH (p:Person) RETURN p")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for row, ok := res.Next(); ok; row, ok = res.Next() {
+		n, err := row.Node(0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		nodes[n.ID] = n.Properties["name"].(string)
+	}
+	res.Close()
+	if nodes[rels[0].SrcID] != "a" || nodes[rels[0].DstID] != "b" {
+		t.Errorf("rel %v -> %v does not join nodes %v", rels[0].SrcID, rels[0].DstID, nodes)
+	}
+
+	name, err := QueryOne[string](ctx, conn, "MATCH (p:Person) WHERE id(p) = $id RETURN p.name", map[string]any{"id": rels[0].DstID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name != "b" {
+		t.Errorf("name = %q, want b", name)
+	}
+	id, err := QueryOne[InternalID](ctx, conn, "MATCH (p:Person {name: 'a'}) RETURN id(p)", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != rels[0].SrcID {
+		t.Errorf("id(p) = %v, want %v", id, rels[0].SrcID)
+	}
+}
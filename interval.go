@@ -0,0 +1,274 @@
+package ladybug
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Interval is an INTERVAL value: a number of months, days and microseconds, kept apart
+// because a month or a day is not a fixed length of time. Row.Value returns INTERVAL
+// columns as Interval; scanning one into a time.Duration is lossy (see ApproxDuration).
+type Interval struct {
+	Months int32
+	Days   int32
+	Micros int64
+}
+
+const (
+	microsPerDay = 24 * 60 * 60 * 1_000_000
+	// daysPerMonth is the month length the library uses for interval arithmetic.
+	daysPerMonth = 30
+)
+
+// IntervalFromDuration returns the Interval of d, truncated to microseconds.
+func IntervalFromDuration(d time.Duration) Interval {
+	return Interval{Micros: d.Microseconds()}
+}
+
+// Duration returns iv as a time.Duration. It reports false when iv has months or days,
+// whose length depends on the calendar, or does not fit in a time.Duration.
+func (iv Interval) Duration() (time.Duration, bool) {
+	if iv.Months != 0 || iv.Days != 0 {
+		return 0, false
+	}
+	if iv.Micros > math.MaxInt64/1000 || iv.Micros < math.MinInt64/1000 {
+		return 0, false
+	}
+	return time.Duration(iv.Micros) * time.Microsecond, true
+}
+
+// ApproxDuration returns iv as a time.Duration counting a month as 30 days and a day as 24
+// hours, as the library does when it compares intervals. The result saturates at the
+// bounds of time.Duration.
+func (iv Interval) ApproxDuration() time.Duration {
+	days := int64(iv.Months)*daysPerMonth + int64(iv.Days)
+	micros := float64(days)*microsPerDay + float64(iv.Micros)
+	ns := micros * float64(time.Microsecond)
+	switch {
+	case ns >= math.MaxInt64:
+		return math.MaxInt64
+	case ns <= math.MinInt64:
+		return math.MinInt64
+	}
+	return time.Duration(days*microsPerDay+iv.Micros) * time.Microsecond
+}
+
+// String returns iv as an ISO-8601 duration such as "P1Y2M3DT4H5M6.5S". Each component
+// carries its own sign ("P-1M2D"); the zero interval is "PT0S".
+func (iv Interval) String() string {
+	if iv == (Interval{}) {
+		return "PT0S"
+	}
+	var b strings.Builder
+	b.WriteByte('P')
+	if y := iv.Months / 12; y != 0 {
+		b.WriteString(strconv.Itoa(int(y)) + "Y")
+	}
+	if m := iv.Months % 12; m != 0 {
+		b.WriteString(strconv.Itoa(int(m)) + "M")
+	}
+	if iv.Days != 0 {
+		b.WriteString(strconv.Itoa(int(iv.Days)) + "D")
+	}
+	if iv.Micros == 0 {
+		return b.String()
+	}
+	b.WriteByte('T')
+	const microsPerMinute = 60 * 1_000_000
+	const microsPerHour = 60 * microsPerMinute
+	if h := iv.Micros / microsPerHour; h != 0 {
+		b.WriteString(strconv.FormatInt(h, 10) + "H")
+	}
+	if m := iv.Micros % microsPerHour / microsPerMinute; m != 0 {
+		b.WriteString(strconv.FormatInt(m, 10) + "M")
+	}
+	if us := iv.Micros % microsPerMinute; us != 0 {
+		if us < 0 {
+			b.WriteByte('-')
+			us = -us
+		}
+		b.WriteString(strconv.FormatInt(us/1_000_000, 10))
+		if frac := us % 1_000_000; frac != 0 {
+			b.WriteString(strings.TrimRight(fmt.Sprintf(".%06d", frac), "0"))
+		}
+		b.WriteByte('S')
+	}
+	return b.String()
+}
+
+// cypherString returns iv in the form the library parses when casting a STRING to INTERVAL,
+// such as "14 months 3 days 4000000 microseconds".
+func (iv Interval) cypherString() string {
+	return fmt.Sprintf("%d months %d days %d microseconds", iv.Months, iv.Days, iv.Micros)
+}
+
+// ParseInterval parses an ISO-8601 duration: P[nY][nM][nW][nD][T[nH][nM][n[.f]S]]. Years
+// count as 12 months and weeks as 7 days. Components may be signed, and a leading "-"
+// negates the whole interval. Seconds may have up to six fractional digits.
+// Each component may appear once, in the order above.
+func ParseInterval(s string) (Interval, error) {
+	bad := func() (Interval, error) {
+		return Interval{}, fmt.Errorf("ladybug: invalid ISO-8601 interval %q", s)
+	}
+	rest := s
+	neg := strings.HasPrefix(rest, "-")
+	if neg || strings.HasPrefix(rest, "+") {
+		rest = rest[1:]
+	}
+	if !strings.HasPrefix(rest, "P") || len(rest) == 1 {
+		return bad()
+	}
+	rest = rest[1:]
+	var months, days, micros int64
+	var ok bool
+	inTime := false
+	last := -1 // position of the previous unit in "YMWDHMS"; units must appear in order
+	for rest != "" {
+		if rest[0] == 'T' {
+			if inTime || len(rest) == 1 {
+				return bad()
+			}
+			inTime = true
+			rest = rest[1:]
+			continue
+		}
+		i := strings.IndexAny(rest, "YMWDHS")
+		if i <= 0 {
+			return bad()
+		}
+		num, unit := rest[:i], rest[i]
+		rest = rest[i+1:]
+		pos := strings.IndexByte("YMWD", unit)
+		if inTime {
+			pos = strings.IndexByte("HMS", unit)
+			if pos >= 0 {
+				pos += 4
+			}
+		}
+		if pos <= last {
+			return bad()
+		}
+		last = pos
+		if unit == 'S' && inTime {
+			us, err := parseSeconds(num)
+			if err != nil {
+				return bad()
+			}
+			if micros, ok = addInt64(micros, us); !ok {
+				return bad()
+			}
+			continue
+		}
+		n, err := strconv.ParseInt(num, 10, 64)
+		if err != nil {
+			return bad()
+		}
+		var mul *int64
+		var scale int64
+		switch {
+		case !inTime && unit == 'Y':
+			mul, scale = &months, 12
+		case !inTime && unit == 'M':
+			mul, scale = &months, 1
+		case !inTime && unit == 'W':
+			mul, scale = &days, 7
+		case !inTime && unit == 'D':
+			mul, scale = &days, 1
+		case inTime && unit == 'H':
+			mul, scale = &micros, 3600*1_000_000
+		case inTime && unit == 'M':
+			mul, scale = &micros, 60*1_000_000
+		default:
+			return bad()
+		}
+		if n > math.MaxInt64/scale || n < math.MinInt64/scale {
+			return bad()
+		}
+		if *mul, ok = addInt64(*mul, n*scale); !ok {
+			return bad()
+		}
+	}
+	if neg {
+		if micros == math.MinInt64 {
+			return bad()
+		}
+		months, days, micros = -months, -days, -micros
+	}
+	if months > math.MaxInt32 || months < math.MinInt32 || days > math.MaxInt32 || days < math.MinInt32 {
+		return bad()
+	}
+	return Interval{Months: int32(months), Days: int32(days), Micros: micros}, nil
+}
+
+// addInt64 returns a + b and whether the sum did not overflow.
+func addInt64(a, b int64) (int64, bool) {
+	sum := a + b
+	return sum, (sum > a) == (b > 0)
+}
+
+// parseSeconds parses "[-]s[.ffffff]" into microseconds.
+func parseSeconds(num string) (int64, error) {
+	neg := strings.HasPrefix(num, "-")
+	if neg || strings.HasPrefix(num, "+") {
+		num = num[1:]
+	}
+	whole, frac, _ := strings.Cut(num, ".")
+	if whole == "" && frac == "" || len(frac) > 6 || strings.ContainsAny(num, "+-") {
+		return 0, fmt.Errorf("invalid seconds %q", num)
+	}
+	var sec, us uint64
+	var err error
+	if whole != "" {
+		if sec, err = strconv.ParseUint(whole, 10, 64); err != nil {
+			return 0, err
+		}
+	}
+	if frac != "" {
+		if us, err = strconv.ParseUint(frac+strings.Repeat("0", 6-len(frac)), 10, 64); err != nil {
+			return 0, err
+		}
+	}
+	if sec > (math.MaxInt64-us)/1_000_000 {
+		return 0, fmt.Errorf("seconds overflow")
+	}
+	total := int64(sec*1_000_000 + us)
+	if neg {
+		total = -total
+	}
+	return total, nil
+}
+
+// MarshalText implements encoding.TextMarshaler using the ISO-8601 form.
+func (iv Interval) MarshalText() ([]byte, error) {
+	return []byte(iv.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (iv *Interval) UnmarshalText(b []byte) error {
+	v, err := ParseInterval(string(b))
+	if err != nil {
+		return err
+	}
+	*iv = v
+	return nil
+}
+
+// Scan implements sql.Scanner for Interval, time.Duration and ISO-8601 string sources.
+func (iv *Interval) Scan(src any) error {
+	switch v := src.(type) {
+	case Interval:
+		*iv = v
+	case time.Duration:
+		*iv = IntervalFromDuration(v)
+	case string:
+		return iv.UnmarshalText([]byte(v))
+	case []byte:
+		return iv.UnmarshalText(v)
+	default:
+		return fmt.Errorf("ladybug: cannot scan %T into Interval", src)
+	}
+	return nil
+}
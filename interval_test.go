@@ -0,0 +1,125 @@
+package ladybug
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestInterval(t *testing.T) {
+	for _, tc := range []struct {
+		iv  Interval
+		out string
+	}{
+		{Interval{}, "PT0S"},
+		{Interval{Months: 14, Days: 3, Micros: 4*3600e6 + 5*60e6 + 6.5e6}, "P1Y2M3DT4H5M6.5S"},
+		{Interval{Months: -1, Days: 2}, "P-1M2D"},
+		{Interval{Micros: -500_000}, "PT-0.5S"},
+		{Interval{Days: 1, Micros: 1}, "P1DT0.000001S"},
+	} {
+		if s := tc.iv.String(); s != tc.out {
+			t.Errorf("String(%+v) = %q, want %q", tc.iv, s, tc.out)
+		}
+		back, err := ParseInterval(tc.out)
+		if err != nil {
+			t.Errorf("ParseInterval(%q): %v", tc.out, err)
+			continue
+		}
+		if back != tc.iv {
+			t.Errorf("ParseInterval(%q) = %+v, want %+v", tc.out, back, tc.iv)
+		}
+	}
+	for in, want := range map[string]Interval{
+		"P2W":      {Days: 14},
+		"-P1DT1H":  {Days: -1, Micros: -3600e6},
+		"PT1.25S":  {Micros: 1_250_000},
+		"PT90M":    {Micros: 90 * 60e6},
+		"P1Y-2M":   {Months: 10},
+		"P1DT2H3M": {Days: 1, Micros: 2*3600e6 + 3*60e6},
+	} {
+		got, err := ParseInterval(in)
+		if err != nil || got != want {
+			t.Errorf("ParseInterval(%q) = %+v, %v; want %+v", in, got, err, want)
+		}
+	}
+	for _, bad := range []string{"", "P", "PT", "P1DT", "1D", "P1H", "PT1D", "PT1.0000001S", "P1.5D", "P--1D", "PT1S2H", "P1D1D", "p1d", "PT2562047788H59M59S", "PT2562047788H1800S"} {
+		if _, err := ParseInterval(bad); err == nil {
+			t.Errorf("ParseInterval(%q) succeeded", bad)
+		}
+	}
+
+	d := 90*time.Minute + 1500*time.Nanosecond
+	iv := IntervalFromDuration(d)
+	if got, ok := iv.Duration(); !ok || got != d.Truncate(time.Microsecond) {
+		t.Errorf("Duration = %v, %v", got, ok)
+	}
+	month := Interval{Months: 1, Micros: 1}
+	if _, ok := month.Duration(); ok {
+		t.Error("Duration of an interval with months succeeded")
+	}
+	if got := month.ApproxDuration(); got != 30*24*time.Hour+time.Microsecond {
+		t.Errorf("ApproxDuration = %v", got)
+	}
+	if got := (Interval{Months: 1 << 30}).ApproxDuration(); got != time.Duration(1<<63-1) {
+		t.Errorf("ApproxDuration did not saturate: %v", got)
+	}
+
+	var dst struct {
+		I Interval
+		D time.Duration
+		P *Interval
+		S Interval
+	}
+	for name, v := range map[string]any{"I": month, "D": month, "P": month, "S": "P1MT0.000001S"} {
+		f, _ := reflect.TypeOf(dst).FieldByName(name)
+		if err := assignValue(reflect.ValueOf(&dst).Elem().FieldByIndex(f.Index), v); err != nil {
+			t.Errorf("assign %s: %v", name, err)
+		}
+	}
+	if dst.I != month || dst.D != month.ApproxDuration() || dst.P == nil || *dst.P != month || dst.S != month {
+		t.Errorf("decoded = %+v", dst)
+	}
+}
+
+func TestIntervalRoundTrip(t *testing.T) {
+	ver, _ := Version()
+	if ver == "" {
+		t.Skip("Ladybug libs not available; skipping")
+	}
+	ctx := context.Background()
+	db, err := Open(ctx, filepath.Join(t.TempDir(), "testdb"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	want := Interval{Months: 13, Days: 2, Micros: 3_000_001}
+	got, err := QueryOne[Interval](ctx, conn, "RETURN $i", map[string]any{"i": want})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != want {
+		t.Errorf("interval round trip = %+v, want %+v", got, want)
+	}
+	got, err = QueryOne[Interval](ctx, conn, "RETURN interval('1 month 2 days')", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != (Interval{Months: 1, Days: 2}) {
+		t.Errorf("interval('1 month 2 days') = %+v", got)
+	}
+	d, err := QueryOne[time.Duration](ctx, conn, "RETURN $d", map[string]any{"d": 90 * time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d != 90*time.Second {
+		t.Errorf("duration round trip = %v", d)
+	}
+}
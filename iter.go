@@ -0,0 +1,64 @@
+package ladybug
+
+import (
+	"iter"
+
+	"github.com/apache/arrow-go/v18/arrow"
+)
+
+// Rows returns an iterator over the remaining rows of the current result set:
+//
+//	for row, err := range res.Rows() {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+//
+// Each Row is valid only for its loop iteration. A failure to fetch a row is yielded as the
+// final element; breaking out of the loop leaves the remaining rows unread.
+func (r *Result) Rows() iter.Seq2[Row, error] {
+	return func(yield func(Row, error) bool) {
+		if r == nil || r.c == nil {
+			yield(Row{}, ErrClosed)
+			return
+		}
+		for {
+			row, ok, err := r.next()
+			if err != nil {
+				yield(Row{}, r.setErr(err))
+				return
+			}
+			if !ok || !yield(row, nil) {
+				return
+			}
+		}
+	}
+}
+
+// Records returns an iterator over the remaining rows as Arrow records of up to chunkSize
+// rows (0 = DefaultArrowChunkSize). Each record is released when the loop body returns, so
+// call Retain on records kept beyond their iteration. Errors are yielded as the final element.
+func (r *Result) Records(chunkSize int64) iter.Seq2[arrow.Record, error] {
+	return func(yield func(arrow.Record, error) bool) {
+		if r == nil || r.c == nil {
+			yield(nil, ErrClosed)
+			return
+		}
+		for {
+			rec, err := r.NextRecord(chunkSize)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if rec == nil {
+				return
+			}
+			more := yield(rec, nil)
+			rec.Release()
+			if !more {
+				return
+			}
+		}
+	}
+}
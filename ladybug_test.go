@@ -2,8 +2,16 @@ package ladybug
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io/fs"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/vkozio/ladybug-go-zero/internal/lbugc"
 )
 
 func TestVersion(t *testing.T) {
@@ -109,3 +117,719 @@ func TestOpenInvalidPath(t *testing.T) {
 		t.Error("Open with empty path should fail")
 	}
 }
+
+func TestConfigValidation(t *testing.T) {
+	ver, _ := Version()
+	if ver == "" {
+		t.Skip("Ladybug libs not available; skipping")
+	}
+	cases := []struct {
+		name string
+		cfg  Config
+	}{
+		{"max db size not power of two", Config{MaxDBSize: 3 << 30}},
+		{"buffer pool exceeds max db size", Config{BufferPoolSize: 2 << 30, MaxDBSize: 1 << 30}},
+		{"threshold with auto checkpoint disabled", Config{DisableAutoCheckpoint: true, CheckpointThreshold: 1 << 20}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.cfg.Path = filepath.Join(t.TempDir(), "db")
+			if _, err := Open(context.Background(), "", &tc.cfg); err == nil {
+				t.Fatal("expected validation error")
+			}
+		})
+	}
+
+	sc, err := (&Config{BufferPoolSize: 256 << 20, MaxNumThreads: 3, DisableCompression: true, ReadOnly: true}).systemConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sc.BufferPoolSize != 256<<20 || sc.MaxNumThreads != 3 || sc.EnableCompression || !sc.ReadOnly {
+		t.Errorf("systemConfig() = %+v", sc)
+	}
+}
+
+func TestOpenConfigHonoured(t *testing.T) {
+	ver, _ := Version()
+	if ver == "" {
+		t.Skip("Ladybug libs not available; skipping")
+	}
+	ctx := context.Background()
+
+	t.Run("MaxNumThreads", func(t *testing.T) {
+		db, err := Open(ctx, filepath.Join(t.TempDir(), "db"), &Config{MaxNumThreads: 3})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer db.Close()
+		conn, err := db.Conn(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer conn.Close()
+		res, err := conn.Query(ctx, "CALL current_setting('threads') RETURN *")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer res.Close()
+		row, ok := res.Next()
+		if !ok {
+			t.Fatal("expected row")
+		}
+		v, err := row.Value(0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fmt.Sprint(v) != "3" {
+			t.Errorf("threads = %v, want 3", v)
+		}
+	})
+
+	// open opens a database with cfg in a new directory and returns its path and a
+	// connection; both are closed when the subtest ends.
+	open := func(t *testing.T, cfg *Config) (string, *Connection) {
+		t.Helper()
+		path := filepath.Join(t.TempDir(), "db")
+		db, err := Open(ctx, path, cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { db.Close() })
+		conn, err := db.Conn(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { conn.Close() })
+		return path, conn
+	}
+	exec := func(conn *Connection, q string) error {
+		res, err := conn.Query(ctx, q)
+		if err == nil {
+			res.Close()
+		}
+		return err
+	}
+	setting := func(t *testing.T, conn *Connection, name string) string {
+		t.Helper()
+		v, err := QueryOne[any](ctx, conn, "CALL current_setting('"+name+"') RETURN *", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return fmt.Sprint(v)
+	}
+
+	t.Run("BufferPoolSize", func(t *testing.T) {
+		const size = 128 << 20
+		_, conn := open(t, &Config{BufferPoolSize: size})
+		type bmInfo struct {
+			BufferPoolSize uint64 `lbug:"buffer_pool_size"`
+		}
+		info, err := QueryOne[bmInfo](ctx, conn, "CALL bm_info() RETURN buffer_pool_size", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.BufferPoolSize != size {
+			t.Errorf("buffer_pool_size = %d, want %d", info.BufferPoolSize, size)
+		}
+	})
+
+	t.Run("DisableCompression", func(t *testing.T) {
+		// The same highly compressible table takes more space on disk without compression.
+		size := func(cfg *Config) int64 {
+			path, conn := open(t, cfg)
+			for _, q := range []string{
+				"CREATE NODE TABLE N(id INT64 PRIMARY KEY, v INT64)",
+				"UNWIND range(1, 100000) AS i CREATE (:N {id: i, v: 0})",
+				"CHECKPOINT",
+			} {
+				if err := exec(conn, q); err != nil {
+					t.Fatal(err)
+				}
+			}
+			files, _ := filepath.Glob(path + "*")
+			var n int64
+			for _, f := range files {
+				_ = filepath.WalkDir(f, func(_ string, d fs.DirEntry, err error) error {
+					if err == nil && !d.IsDir() {
+						if fi, err := d.Info(); err == nil {
+							n += fi.Size()
+						}
+					}
+					return nil
+				})
+			}
+			return n
+		}
+		compressed, plain := size(nil), size(&Config{DisableCompression: true})
+		if plain <= compressed {
+			t.Errorf("size without compression = %d, with compression = %d; want larger", plain, compressed)
+		}
+	})
+
+	t.Run("MaxDBSize", func(t *testing.T) {
+		// About 20 MiB of strings fit in the default limit but not in 1 MiB.
+		fill := func(cfg *Config) error {
+			db, err := Open(ctx, filepath.Join(t.TempDir(), "db"), cfg)
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+			conn, err := db.Conn(ctx)
+			if err != nil {
+				return err
+			}
+			defer conn.Close()
+			for _, q := range []string{
+				"CREATE NODE TABLE S(id INT64 PRIMARY KEY, s STRING)",
+				"UNWIND range(1, 2000) AS i CREATE (:S {id: i, s: concat(repeat('x', 10000), string(i))})",
+				"CHECKPOINT",
+			} {
+				if err := exec(conn, q); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		if err := fill(nil); err != nil {
+			t.Fatal(err)
+		}
+		if err := fill(&Config{MaxDBSize: 1 << 20}); err == nil {
+			t.Error("20 MiB written to a database limited to 1 MiB")
+		}
+	})
+
+	t.Run("Checkpoint", func(t *testing.T) {
+		_, conn := open(t, &Config{CheckpointThreshold: 1 << 20})
+		if got := setting(t, conn, "checkpoint_threshold"); got != fmt.Sprint(1<<20) {
+			t.Errorf("checkpoint_threshold = %s, want %d", got, 1<<20)
+		}
+		if got := setting(t, conn, "auto_checkpoint"); !strings.EqualFold(got, "true") {
+			t.Errorf("auto_checkpoint = %s, want true", got)
+		}
+		_, conn = open(t, &Config{DisableAutoCheckpoint: true})
+		if got := setting(t, conn, "auto_checkpoint"); !strings.EqualFold(got, "false") {
+			t.Errorf("auto_checkpoint = %s with DisableAutoCheckpoint, want false", got)
+		}
+	})
+
+	t.Run("ReadOnly", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "db")
+		db, err := Open(ctx, path, &Config{DisableCompression: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		conn, err := db.Conn(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res, err := conn.Query(ctx, "CREATE NODE TABLE Person(name STRING, PRIMARY KEY(name))")
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Close()
+		conn.Close()
+		db.Close()
+
+		db, err = Open(ctx, path, &Config{ReadOnly: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer db.Close()
+		conn, err = db.Conn(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer conn.Close()
+		if res, err := conn.Query(ctx, "CREATE (:Person {name: 'a'})"); err == nil {
+			res.Close()
+			t.Fatal("write on read-only database should fail")
+		}
+		res, err = conn.Query(ctx, "MATCH (p:Person) RETURN count(p)")
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Close()
+	})
+}
+
+func TestNextResultSet(t *testing.T) {
+	ver, _ := Version()
+	if ver == "" {
+		t.Skip("Ladybug libs not available; skipping")
+	}
+	ctx := context.Background()
+	db, err := Open(ctx, filepath.Join(t.TempDir(), "multi"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	res, err := conn.Query(ctx, "RETURN 1 AS a; RETURN 'two' AS b; UNWIND range(1, 3) AS c RETURN c")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Close()
+
+	var got []any
+	for {
+		for row, ok := res.Next(); ok; row, ok = res.Next() {
+			v, err := row.Value(0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got = append(got, v)
+		}
+		if s, err := res.Summary(); err != nil || s == nil {
+			t.Fatalf("Summary() = %v, %v", s, err)
+		}
+		if !res.HasNextResultSet() {
+			break
+		}
+		ok, err := res.NextResultSet()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !ok {
+			t.Fatal("HasNextResultSet reported true but NextResultSet returned false")
+		}
+	}
+	want := []any{int64(1), "two", int64(1), int64(2), int64(3)}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if ok, err := res.NextResultSet(); ok || err != nil {
+		t.Errorf("NextResultSet() after last = %v, %v", ok, err)
+	}
+
+	// A failing later statement surfaces either from Query or from NextResultSet.
+	res2, err := conn.Query(ctx, "RETURN 1; RETURN nonexistent_fn()")
+	if err == nil {
+		defer res2.Close()
+		if _, err := res2.NextResultSet(); err == nil {
+			t.Error("expected error from failing second statement")
+		}
+	}
+}
+
+func TestLogicalTypeFromArrow(t *testing.T) {
+	dt := arrow.StructOf(
+		arrow.Field{Name: "ids", Type: arrow.ListOf(arrow.PrimitiveTypes.Int64)},
+		arrow.Field{Name: "vec", Type: arrow.FixedSizeListOf(3, arrow.PrimitiveTypes.Float32)},
+		arrow.Field{Name: "tags", Type: arrow.MapOf(arrow.BinaryTypes.String, arrow.PrimitiveTypes.Int32)},
+		arrow.Field{Name: "at", Type: &arrow.TimestampType{Unit: arrow.Microsecond, TimeZone: "UTC"}},
+	)
+	got := logicalTypeFromArrow(dt).String()
+	want := "STRUCT(ids INT64[], vec FLOAT[3], tags MAP(STRING, INT32), at TIMESTAMP_TZ)"
+	if got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func TestResultColumns(t *testing.T) {
+	ver, _ := Version()
+	if ver == "" {
+		t.Skip("Ladybug libs not available; skipping")
+	}
+	ctx := context.Background()
+	db, err := Open(ctx, filepath.Join(t.TempDir(), "cols"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	res, err := conn.Query(ctx, "RETURN 1 AS n, [1, 2] AS l, {a: 'x', b: 2.5} AS s, CAST([1.0, 2.0, 3.0] AS DOUBLE[3]) AS arr LIMIT 0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Close()
+	cols, err := res.Columns()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []struct {
+		name, typ string
+	}{
+		{"n", "INT64"},
+		{"l", "INT64[]"},
+		{"s", "STRUCT(a STRING, b DOUBLE)"},
+		{"arr", "DOUBLE[3]"},
+	}
+	if len(cols) != len(want) {
+		t.Fatalf("got %d columns, want %d", len(cols), len(want))
+	}
+	for i, w := range want {
+		if cols[i].Name != w.name || cols[i].Type.String() != w.typ {
+			t.Errorf("column %d = %s %s, want %s %s", i, cols[i].Name, cols[i].Type, w.name, w.typ)
+		}
+	}
+	if cols[3].Type.ArrayLen != 3 {
+		t.Errorf("ArrayLen = %d, want 3", cols[3].Type.ArrayLen)
+	}
+}
+
+func TestErrorClassification(t *testing.T) {
+	cases := []struct {
+		msg      string
+		sentinel error
+		line     int
+		column   int
+	}{
+		{"Parser exception: Invalid input <RETURN 1 +>: expected rule oC_Expression (line: 1, offset: 10)", ErrSyntax, 1, 11},
+		{"Binder exception: Variable x is not in scope.", ErrBinder, 0, 0},
+		{"Catalog exception: Table Person does not exist.", ErrCatalog, 0, 0},
+		{"Runtime exception: Found duplicated primary key value 1", ErrRuntime, 0, 0},
+		{"Copy exception: Unable to find primary key value 7.", ErrRuntime, 0, 0},
+		{"Interrupted.", ErrInterrupted, 0, 0},
+		{"Query timeout reached.", ErrTimeout, 0, 0},
+		{"Transaction Manager exception: Cannot start a new write transaction in the system.", ErrTransaction, 0, 0},
+		{"Runtime exception: Write-write conflict of updating the same rel.", ErrTransaction, 0, 0},
+		// Values quoted in messages do not change the kind.
+		{"Runtime exception: Found duplicated primary key value timeout", ErrRuntime, 0, 0},
+		{"Copy exception: Unable to find primary key value interrupted.", ErrRuntime, 0, 0},
+		{"Binder exception: Cannot find property timeout for p.", ErrBinder, 0, 0},
+	}
+	for _, tc := range cases {
+		err := wrapErr(&lbugc.Error{Op: "query", Message: tc.msg})
+		if !errors.Is(err, tc.sentinel) {
+			t.Errorf("%q: errors.Is(%v) = false", tc.msg, tc.sentinel)
+		}
+		var e *Error
+		if !errors.As(err, &e) {
+			t.Fatalf("%q: not an *Error", tc.msg)
+		}
+		if e.Line != tc.line || e.Column != tc.column || e.Message != tc.msg || e.Op != "query" {
+			t.Errorf("%q: got %+v", tc.msg, e)
+		}
+	}
+
+	err := wrapErr(&lbugc.Error{Op: "connection_init", Message: "database closed"})
+	if !errors.Is(err, ErrConnection) {
+		t.Errorf("connection_init error kind = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = wrapCtxErr(ctx, &lbugc.Error{Op: "query", Message: "Interrupted."})
+	if !errors.Is(err, ErrInterrupted) || !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled interrupt = %v", err)
+	}
+}
+
+func TestQueryErrorKinds(t *testing.T) {
+	ver, _ := Version()
+	if ver == "" {
+		t.Skip("Ladybug libs not available; skipping")
+	}
+	ctx := context.Background()
+	db, err := Open(ctx, filepath.Join(t.TempDir(), "errs"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	_, err = conn.Query(ctx, "RETURN 1 +")
+	var e *Error
+	if !errors.As(err, &e) || e.Kind != KindSyntax {
+		t.Fatalf("syntax error = %#v", err)
+	}
+	if e.Line == 0 {
+		t.Errorf("expected line information in %q", e.Message)
+	}
+	if _, err := conn.Query(ctx, "RETURN x"); !errors.Is(err, ErrBinder) {
+		t.Errorf("binder error = %v", err)
+	}
+	if _, err := conn.Query(ctx, "MATCH (n:Missing) RETURN n"); !errors.Is(err, ErrBinder) && !errors.Is(err, ErrCatalog) {
+		t.Errorf("missing table error = %v", err)
+	}
+}
+
+func TestResultErr(t *testing.T) {
+	ver, _ := Version()
+	if ver == "" {
+		t.Skip("Ladybug libs not available; skipping")
+	}
+	ctx := context.Background()
+	db, err := Open(ctx, filepath.Join(t.TempDir(), "reserr"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	res, err := conn.Query(ctx, "UNWIND range(1, 3) AS x RETURN x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := 0
+	for row, ok := res.Next(); ok; row, ok = res.Next() {
+		n++
+		if _, err := row.Int64(0); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := res.Err(); err != nil || n != 3 {
+		t.Fatalf("clean iteration: n=%d err=%v", n, err)
+	}
+	res.Close()
+
+	res, err = conn.Query(ctx, "RETURN 1 AS x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	row, ok := res.Next()
+	if !ok {
+		t.Fatal("expected row")
+	}
+	valErr := row.Scan(new(int64), new(int64))
+	if valErr == nil {
+		t.Fatal("expected Scan error for missing column")
+	}
+	if _, err := row.Value(7); err == nil {
+		t.Fatal("expected Value error for out-of-range column")
+	}
+	res.Close()
+	if res.Err() == nil {
+		t.Error("Err() should report the Value failure after Close")
+	}
+}
+
+func TestStructFields(t *testing.T) {
+	type Base struct {
+		ID   int64
+		Name string `lbug:"base_name"`
+	}
+	type Item struct {
+		Base
+		Name    string `lbug:"name"`
+		Skipped int    `lbug:"-"`
+		hidden  int
+		Score   *float64
+	}
+	fs := structFields(reflect.TypeOf(Item{}))
+	var names []string
+	for _, f := range fs {
+		names = append(names, f.name)
+	}
+	want := []string{"ID", "base_name", "name", "Score"}
+	if fmt.Sprint(names) != fmt.Sprint(want) {
+		t.Errorf("fields = %v, want %v", names, want)
+	}
+}
+
+func TestBindComposite(t *testing.T) {
+	ver, _ := Version()
+	if ver == "" {
+		t.Skip("Ladybug libs not available; skipping")
+	}
+	ctx := context.Background()
+	db, err := Open(ctx, filepath.Join(t.TempDir(), "testdb"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	type person struct {
+		Name string `lbug:"name"`
+		Age  int16  `lbug:"age"`
+	}
+	var missing *int32
+	cases := []struct {
+		query string
+		arg   any
+		want  string
+	}{
+		{"UNWIND $p AS x RETURN sum(x)", []int32{1, 2, 3}, "6"},
+		{"UNWIND $p AS r RETURN r.name + ':' + CAST(r.age AS STRING)", []person{{"ann", 30}, {"bob", 41}}, "ann:30|bob:41"},
+		{"RETURN $p.city", map[string]any{"city": "Oslo", "zip": 150}, "Oslo"},
+		{"RETURN $p IS NULL", missing, "true"},
+		{"RETURN size($p)", []string{}, "0"},
+		{"RETURN $p + 1", uint8(254), "255"},
+		{"RETURN element_at($p, 2)[1]", map[int64]string{2: "two"}, "two"},
+	}
+	for _, tc := range cases {
+		ps, err := conn.Prepare(ctx, tc.query)
+		if err != nil {
+			t.Fatalf("%s: prepare: %v", tc.query, err)
+		}
+		if err := ps.Bind("p", tc.arg); err != nil {
+			ps.Close()
+			t.Fatalf("%s: bind: %v", tc.query, err)
+		}
+		res, err := ps.Execute(ctx)
+		if err != nil {
+			ps.Close()
+			t.Fatalf("%s: execute: %v", tc.query, err)
+		}
+		var got []string
+		for {
+			row, ok := res.Next()
+			if !ok {
+				break
+			}
+			v, err := row.Value(0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got = append(got, fmt.Sprint(v))
+		}
+		res.Close()
+		ps.Close()
+		if g := strings.Join(got, "|"); g != tc.want {
+			t.Errorf("%s = %q, want %q", tc.query, g, tc.want)
+		}
+	}
+
+	ps, err := conn.Prepare(ctx, "RETURN $p")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ps.Close()
+	if err := ps.Bind("p", []byte("x")); err == nil {
+		t.Error("binding []byte: want error")
+	}
+	if err := ps.Bind("p", make(chan int)); err == nil {
+		t.Error("binding chan: want error")
+	}
+	if err := ps.Bind("p", map[string]any{}); err == nil || err.Error() != "ladybug: bind $p: empty map is not supported" {
+		t.Errorf("binding empty map: err = %v", err)
+	}
+}
+
+func TestBindEmptyComposite(t *testing.T) {
+	type hidden struct{ x int }
+	for _, v := range []any{map[string]any{}, map[int64]string{}, hidden{}, []map[string]int{{}}} {
+		if _, err := toValue(reflect.ValueOf(v)); err == nil {
+			t.Errorf("toValue(%#v) succeeded", v)
+		}
+	}
+}
+
+func TestQueryParams(t *testing.T) {
+	ver, _ := Version()
+	if ver == "" {
+		t.Skip("Ladybug libs not available; skipping")
+	}
+	ctx := context.Background()
+	db, err := Open(ctx, filepath.Join(t.TempDir(), "testdb"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	res, err := conn.QueryParams(ctx, "RETURN $a + $b AS s, $name AS n", map[string]any{"a": 2, "b": int64(3), "name": "x"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	row, ok := res.Next()
+	if !ok {
+		t.Fatal("expected one row")
+	}
+	if s, _ := row.Int64(0); s != 5 {
+		t.Errorf("s = %d, want 5", s)
+	}
+	if n, _ := row.String(1); n != "x" {
+		t.Errorf("n = %q, want x", n)
+	}
+	res.Close()
+
+	res, err = conn.QueryNamed(ctx, "UNWIND $xs AS x RETURN count(x)", Named("xs", []int{1, 2, 3}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	row, ok = res.Next()
+	if !ok {
+		t.Fatal("expected one row")
+	}
+	if n, _ := row.Int64(0); n != 3 {
+		t.Errorf("count = %d, want 3", n)
+	}
+	res.Close()
+
+	if _, err := conn.QueryNamed(ctx, "RETURN $x", Named("x", make(chan int))); err == nil {
+		t.Error("unsupported parameter: want error")
+	}
+}
+
+func TestResultIterators(t *testing.T) {
+	ver, _ := Version()
+	if ver == "" {
+		t.Skip("Ladybug libs not available; skipping")
+	}
+	ctx := context.Background()
+	db, err := Open(ctx, filepath.Join(t.TempDir(), "testdb"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	res, err := conn.Query(ctx, "UNWIND range(1, 10) AS x RETURN x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sum int64
+	for row, err := range res.Rows() {
+		if err != nil {
+			t.Fatal(err)
+		}
+		x, _ := row.Int64(0)
+		sum += x
+		if x == 4 {
+			break
+		}
+	}
+	if sum != 10 {
+		t.Errorf("sum before break = %d, want 10", sum)
+	}
+	res.Close()
+
+	res, err = conn.Query(ctx, "UNWIND range(1, 10) AS x RETURN x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Close()
+	var rows, batches int64
+	for rec, err := range res.Records(3) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		rows += rec.NumRows()
+		batches++
+	}
+	if rows != 10 || batches != 4 {
+		t.Errorf("Records(3) = %d rows in %d batches, want 10 in 4", rows, batches)
+	}
+}
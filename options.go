@@ -0,0 +1,170 @@
+package ladybug
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// QueryOption changes a setting for a single call of Query, Execute or one of the helpers
+// built on them; the connection's own settings are restored when the call returns. Calls
+// with WithTimeout, WithMaxThreads, WithReadOnly or a context deadline run alone on the
+// connection: concurrent calls on it wait until they return or their context ends.
+type QueryOption func(*queryOptions)
+
+type queryOptions struct {
+	timeout    time.Duration
+	maxThreads uint64
+	chunkSize  int64
+	tag        string
+	readOnly   bool
+	location   *time.Location
+}
+
+// WithTimeout limits the query to d. The shortest of d, the connection's SetQueryTimeout
+// and the context deadline applies.
+func WithTimeout(d time.Duration) QueryOption {
+	return func(o *queryOptions) { o.timeout = d }
+}
+
+// WithMaxThreads limits the threads used to execute the query (0 = keep the connection's limit).
+func WithMaxThreads(n uint64) QueryOption {
+	return func(o *queryOptions) { o.maxThreads = n }
+}
+
+// WithArrowChunkSize sets the number of rows per Arrow record returned by the Result when
+// NextRecord, Records or RecordReader are called with a chunk size of 0.
+func WithArrowChunkSize(n int64) QueryOption {
+	return func(o *queryOptions) { o.chunkSize = n }
+}
+
+// WithTag labels the query. The tag is available from the context passed to
+// Config.OnQueryFinished through QueryTag.
+func WithTag(tag string) QueryOption {
+	return func(o *queryOptions) { o.tag = tag }
+}
+
+// WithReadOnly runs the query in a read-only transaction, so that it fails instead of
+// writing. Inside a Tx it is a no-op for read-only transactions and an error otherwise.
+func WithReadOnly() QueryOption {
+	return func(o *queryOptions) { o.readOnly = true }
+}
+
+// WithLocation renders the query's timestamps in loc, overriding Config.Location.
+func WithLocation(loc *time.Location) QueryOption {
+	return func(o *queryOptions) { o.location = loc }
+}
+
+type queryTagKey struct{}
+
+// QueryTag returns the tag set with WithTag for the query the context belongs to, or "".
+func QueryTag(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	tag, _ := ctx.Value(queryTagKey{}).(string)
+	return tag
+}
+
+// applyOptions applies opts and the context deadline to c for one call. It returns the
+// context to report to hooks, the parsed options and a function restoring c's settings.
+func (c *Connection) applyOptions(ctx context.Context, opts []QueryOption) (context.Context, queryOptions, func(), error) {
+	var o queryOptions
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&o)
+		}
+	}
+	if o.timeout < 0 || o.chunkSize < 0 {
+		return ctx, o, nil, fmt.Errorf("ladybug: query options must not be negative")
+	}
+	if o.location == nil && c.cfg != nil {
+		o.location = c.cfg.Location
+	}
+	if o.tag != "" {
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		ctx = context.WithValue(ctx, queryTagKey{}, o.tag)
+	}
+
+	// A call that changes connection settings for its duration (timeout, threads, read-only
+	// transaction) holds mu exclusively, so that no other call runs under those settings or
+	// has its own overwritten; other calls share mu. Waiting for mu ends with ctx.
+	exclusive := o.timeout > 0 || o.maxThreads > 0 || o.readOnly
+	if ctx != nil {
+		if _, ok := ctx.Deadline(); ok {
+			exclusive = true
+		}
+	}
+	if err := c.mu.lock(ctx, exclusive); err != nil {
+		return ctx, o, nil, err
+	}
+	undo := []func(){func() { c.mu.unlock(exclusive) }}
+	restore := func() {
+		for i := len(undo) - 1; i >= 0; i-- {
+			undo[i]()
+		}
+	}
+	fail := func(err error) (context.Context, queryOptions, func(), error) {
+		restore()
+		return ctx, o, nil, err
+	}
+
+	timeout := c.timeout
+	if o.timeout > 0 && (timeout == 0 || o.timeout < timeout) {
+		timeout = o.timeout
+	}
+	if ctx != nil {
+		if deadline, ok := ctx.Deadline(); ok {
+			// Round up: a timeout of 0 would disable the limit.
+			left := max(time.Until(deadline), time.Millisecond)
+			if timeout == 0 || left < timeout {
+				timeout = left
+			}
+		}
+	}
+	if timeout != c.timeout {
+		if err := c.c.SetQueryTimeout(uint64(max(timeout.Milliseconds(), 1))); err != nil {
+			return fail(wrapErr(err))
+		}
+		undo = append(undo, func() { _ = c.c.SetQueryTimeout(uint64(c.timeout.Milliseconds())) })
+	}
+
+	if o.maxThreads > 0 {
+		prev, err := c.c.MaxNumThreadForExec()
+		if err != nil {
+			return fail(wrapErr(err))
+		}
+		if prev != o.maxThreads {
+			if err := c.c.SetMaxNumThreadForExec(o.maxThreads); err != nil {
+				return fail(fmt.Errorf("ladybug: max threads %d: %w", o.maxThreads, wrapErr(err)))
+			}
+			undo = append(undo, func() { _ = c.c.SetMaxNumThreadForExec(prev) })
+		}
+	}
+
+	if o.readOnly {
+		if tx := c.tx.Load(); tx != nil && !tx.done.Load() {
+			if !tx.readOnly {
+				return fail(fmt.Errorf("ladybug: WithReadOnly inside a read-write transaction"))
+			}
+		} else {
+			if err := c.exec("BEGIN TRANSACTION READ ONLY"); err != nil {
+				return fail(err)
+			}
+			undo = append(undo, func() { _ = c.exec("COMMIT") })
+		}
+	}
+	return ctx, o, restore, nil
+}
+
+// exec runs a statement directly, without hooks or statement cache invalidation.
+func (c *Connection) exec(stmt string) error {
+	res, err := c.c.Query(stmt)
+	if err != nil {
+		return wrapErr(err)
+	}
+	res.Close()
+	return nil
+}
@@ -0,0 +1,133 @@
+package ladybug
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestQueryTag(t *testing.T) {
+	if tag := QueryTag(context.Background()); tag != "" {
+		t.Errorf("QueryTag(Background) = %q", tag)
+	}
+	ctx := context.WithValue(context.Background(), queryTagKey{}, "report")
+	if tag := QueryTag(ctx); tag != "report" {
+		t.Errorf("QueryTag = %q, want report", tag)
+	}
+}
+
+func TestQueryOptions(t *testing.T) {
+	ver, _ := Version()
+	if ver == "" {
+		t.Skip("Ladybug libs not available; skipping")
+	}
+	ctx := context.Background()
+	var (
+		conn    *Connection
+		tags    []string
+		hookErr error
+	)
+	db, err := Open(ctx, filepath.Join(t.TempDir(), "testdb"), &Config{
+		OnQueryFinished: func(ctx context.Context, _ string, _ QuerySummary, _ error) {
+			tags = append(tags, QueryTag(ctx))
+			if QueryTag(ctx) == "probe" {
+				// The connection is released before hooks run, even after exclusive calls.
+				var res *Result
+				if res, hookErr = conn.Query(context.Background(), "RETURN 2"); hookErr == nil {
+					res.Close()
+				}
+			}
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	conn, err = db.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	res, err := conn.Query(ctx, "CREATE NODE TABLE Person(name STRING PRIMARY KEY)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Close()
+
+	if _, err := conn.Query(ctx, "RETURN 1", WithTimeout(-time.Second)); err == nil {
+		t.Error("expected error for negative timeout")
+	}
+
+	tags = nil
+	res, err = conn.Query(ctx, "RETURN 1", WithTag("probe"), WithTimeout(time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Close()
+	if len(tags) != 2 || tags[0] != "probe" || tags[1] != "" {
+		t.Errorf("hook tags = %v, want [probe, \"\"]", tags)
+	}
+	if hookErr != nil {
+		t.Errorf("query from hook: %v", hookErr)
+	}
+
+	if _, err := conn.Query(ctx, "CREATE (:Person {name: 'ann'})", WithReadOnly()); err == nil {
+		t.Error("write with WithReadOnly succeeded")
+	}
+	n, err := QueryOne[int64](ctx, conn, "MATCH (p:Person) RETURN count(p)", nil, WithReadOnly())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 0 {
+		t.Errorf("count = %d, want 0", n)
+	}
+	// The read-only transaction was closed: writes work again.
+	res, err = conn.Query(ctx, "CREATE (:Person {name: 'ann'})")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Close()
+
+	tx, err := conn.BeginTx(ctx, TxOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tx.Query(ctx, "RETURN 1", WithReadOnly()); err == nil {
+		t.Error("WithReadOnly inside read-write transaction succeeded")
+	}
+	_ = tx.Rollback()
+
+	res, err = conn.Query(ctx, "UNWIND range(1, 10) AS i RETURN i", WithArrowChunkSize(4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Close()
+	var sizes []int64
+	for rec, err := range res.Records(0) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		sizes = append(sizes, rec.NumRows())
+	}
+	if len(sizes) != 3 || sizes[0] != 4 {
+		t.Errorf("record sizes = %v, want [4 4 2]", sizes)
+	}
+
+	// A deadline applies to its own call only: a slow query without one afterwards runs to
+	// completion on the same connection.
+	const slow = "UNWIND range(1, 50000000) AS x RETURN sum(x)"
+	dctx, cancel := context.WithTimeout(ctx, time.Millisecond)
+	if res, err := conn.Query(dctx, slow); err == nil {
+		res.Close()
+		t.Error("slow query with 1ms deadline succeeded")
+	}
+	cancel()
+	sum, err := QueryOne[int64](ctx, conn, slow, nil)
+	if err != nil {
+		t.Fatalf("slow query after deadline query: %v", err)
+	}
+	if sum != 50000000*50000001/2 {
+		t.Errorf("sum = %d", sum)
+	}
+}
@@ -0,0 +1,75 @@
+package ladybug
+
+import "fmt"
+
+// Path is a RECURSIVE_REL value, such as a variable-length or shortest path: its nodes and
+// relationships in traversal order. For a path variable (MATCH p = (a)-[*]->(b) RETURN p)
+// Nodes holds every node from a to b; for a relationship variable ([r*]) Ladybug lists only
+// the intermediate nodes.
+type Path struct {
+	Nodes []Node
+	Rels  []Rel
+}
+
+// Len returns the number of relationships in the path.
+func (p Path) Len() int {
+	return len(p.Rels)
+}
+
+// Start returns the first node of the path, or the zero Node if it has none.
+func (p Path) Start() Node {
+	if len(p.Nodes) == 0 {
+		return Node{}
+	}
+	return p.Nodes[0]
+}
+
+// End returns the last node of the path, or the zero Node if it has none.
+func (p Path) End() Node {
+	if len(p.Nodes) == 0 {
+		return Node{}
+	}
+	return p.Nodes[len(p.Nodes)-1]
+}
+
+// AsPath attempts to interpret v as a Path returned by the driver.
+func AsPath(v any) (Path, bool) {
+	m, ok := v.(map[string]any)
+	if !ok {
+		return Path{}, false
+	}
+	rawNodes, okNodes := m["nodes"].([]any)
+	rawRels, okRels := m["rels"].([]any)
+	if !okNodes || !okRels {
+		return Path{}, false
+	}
+	p := Path{Nodes: make([]Node, 0, len(rawNodes)), Rels: make([]Rel, 0, len(rawRels))}
+	for _, el := range rawNodes {
+		n, ok := AsNode(el)
+		if !ok {
+			return Path{}, false
+		}
+		p.Nodes = append(p.Nodes, n)
+	}
+	for _, el := range rawRels {
+		r, ok := AsRel(el)
+		if !ok {
+			return Path{}, false
+		}
+		p.Rels = append(p.Rels, r)
+	}
+	return p, true
+}
+
+// Path returns the RECURSIVE_REL value at the given column index.
+func (row Row) Path(index int) (Path, error) {
+	v, err := row.Value(uint64(index))
+	if err != nil {
+		return Path{}, err
+	}
+	p, ok := AsPath(v)
+	if !ok {
+		return Path{}, fmt.Errorf("ladybug: column %d is not Path (got %T)", index, v)
+	}
+	return p, nil
+}
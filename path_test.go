@@ -0,0 +1,94 @@
+package ladybug
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestAsPath(t *testing.T) {
+	node := func(name string) map[string]any {
+		return map[string]any{"id": name, "labels": []string{"Person"}, "properties": map[string]any{"name": name}}
+	}
+	rel := map[string]any{"id": "r", "src_id": "a", "dst_id": "b", "label": "Knows", "properties": map[string]any{}}
+	p, ok := AsPath(map[string]any{"nodes": []any{node("a"), node("b")}, "rels": []any{rel}})
+	if !ok {
+		t.Fatal("AsPath failed")
+	}
+	if p.Len() != 1 || p.Start().Properties["name"] != "a" || p.End().Properties["name"] != "b" {
+		t.Errorf("path = %+v", p)
+	}
+	if _, ok := AsPath(map[string]any{"nodes": []any{}}); ok {
+		t.Error("AsPath accepted a map without rels")
+	}
+	if (Path{}).Start().Labels != nil {
+		t.Error("Start of empty path is not the zero Node")
+	}
+
+	// The Arrow layout of a RECURSIVE_REL is reshaped like Row.Value's.
+	g := graphValue(map[string]any{"_NODES": []any{}, "_RELS": []any{}})
+	if _, ok := AsPath(g); !ok {
+		t.Errorf("graphValue(RECURSIVE_REL) = %v", g)
+	}
+	var dst Path
+	if err := assignValue(reflect.ValueOf(&dst).Elem(), g); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRowPath(t *testing.T) {
+	ver, _ := Version()
+	if ver == "" {
+		t.Skip("Ladybug libs not available; skipping")
+	}
+	ctx := context.Background()
+	db, err := Open(ctx, filepath.Join(t.TempDir(), "testdb"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	for _, q := range []string{
+		"CREATE NODE TABLE Person(name STRING PRIMARY KEY)",
+		"CREATE REL TABLE Knows(FROM Person TO Person)",
+		"CREATE (:Person {name: 'a'})-[:Knows]->(:Person {name: 'b'})",
+		"MATCH (b:Person {name: 'b'}) CREATE (b)-[:Knows]->(:Person {name: 'c'})",
+	} {
+		res, err := conn.Query(ctx, q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Close()
+	}
+	res, err := conn.Query(ctx, "MATCH p = (a:Person {name: 'a'})-[:Knows* SHORTEST 1..3]->(c:Person {name: 'c'}) RETURN p")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Close()
+	row, ok := res.Next()
+	if !ok {
+		t.Fatal("expected one row")
+	}
+	p, err := row.Path(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Len() != 2 {
+		t.Errorf("Len = %d, want 2", p.Len())
+	}
+	if name, _ := p.End().Property("name"); len(p.Nodes) == 3 && name != "c" {
+		t.Errorf("End name = %v, want c", name)
+	}
+	var scanned Path
+	if err := row.Scan(&scanned); err != nil {
+		t.Fatal(err)
+	}
+	if scanned.Len() != p.Len() {
+		t.Errorf("Scan Len = %d, want %d", scanned.Len(), p.Len())
+	}
+}
@@ -0,0 +1,279 @@
+package ladybug
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// PoolConfig configures a Pool. The zero value is valid.
+type PoolConfig struct {
+	// MaxOpen is the maximum number of connections, idle or in use (0 = unlimited).
+	// Acquire blocks while MaxOpen connections are in use.
+	MaxOpen int
+	// MaxIdle is the maximum number of idle connections kept for reuse (0 = 2).
+	MaxIdle int
+	// IdleTimeout closes connections that have been idle this long (0 = never).
+	IdleTimeout time.Duration
+	// HealthCheck validates an idle connection before Acquire hands it out; a connection that
+	// fails it is closed and replaced. nil runs `RETURN 1`.
+	HealthCheck func(ctx context.Context, conn *Connection) error
+	// HealthCheckIdle skips HealthCheck for connections idle for less than this
+	// (0 = check on every Acquire).
+	HealthCheckIdle time.Duration
+}
+
+// PoolStats describes the state of a Pool.
+type PoolStats struct {
+	MaxOpen int
+	// Open is the number of open connections; InUse of them are acquired and Idle are not.
+	Open  int
+	InUse int
+	Idle  int
+	// Acquires is the number of successful Acquire calls.
+	Acquires int64
+	// WaitCount is the number of Acquire calls that had to wait for a connection, and
+	// WaitDuration the total time they waited.
+	WaitCount    int64
+	WaitDuration time.Duration
+	// IdleClosed counts connections closed by MaxIdle or IdleTimeout; HealthCheckFailed
+	// counts connections closed because HealthCheck failed.
+	IdleClosed        int64
+	HealthCheckFailed int64
+}
+
+// Pool is a set of connections to a Database that can be shared by many goroutines. Each
+// Acquire hands out a connection for exclusive use until its Close, which resets the
+// connection's per-call state (query timeout, thread limit, open transaction) and returns it to the pool.
+type Pool struct {
+	db  *Database
+	cfg PoolConfig
+	// sem holds one token per open connection when MaxOpen > 0.
+	sem  chan struct{}
+	stop chan struct{}
+
+	mu     sync.Mutex
+	idle   []*pooledConn // most recently used last
+	open   int
+	closed bool
+	stats  PoolStats
+}
+
+type pooledConn struct {
+	conn      *Connection
+	idleSince time.Time
+}
+
+// NewPool returns a pool of connections to db. Close the pool before closing db.
+func NewPool(db *Database, cfg *PoolConfig) (*Pool, error) {
+	if db == nil || db.c == nil {
+		return nil, ErrClosed
+	}
+	p := &Pool{db: db, stop: make(chan struct{})}
+	if cfg != nil {
+		p.cfg = *cfg
+	}
+	if p.cfg.MaxOpen < 0 || p.cfg.MaxIdle < 0 || p.cfg.IdleTimeout < 0 || p.cfg.HealthCheckIdle < 0 {
+		return nil, fmt.Errorf("ladybug: PoolConfig values must not be negative")
+	}
+	if p.cfg.MaxIdle == 0 {
+		p.cfg.MaxIdle = 2
+	}
+	if p.cfg.MaxOpen > 0 {
+		p.sem = make(chan struct{}, p.cfg.MaxOpen)
+		p.cfg.MaxIdle = min(p.cfg.MaxIdle, p.cfg.MaxOpen)
+	}
+	if p.cfg.HealthCheck == nil {
+		p.cfg.HealthCheck = ping
+	}
+	if p.cfg.IdleTimeout > 0 {
+		go p.reapIdle()
+	}
+	return p, nil
+}
+
+// Acquire returns a connection from the pool, opening one if none is idle. When MaxOpen
+// connections are in use it waits until one is returned or ctx is done. Call
+// Connection.Close to return the connection.
+func (p *Pool) Acquire(ctx context.Context) (*Connection, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if p.sem != nil {
+		select {
+		case p.sem <- struct{}{}:
+		default:
+			start := time.Now()
+			select {
+			case p.sem <- struct{}{}:
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+			p.mu.Lock()
+			p.stats.WaitCount++
+			p.stats.WaitDuration += time.Since(start)
+			p.mu.Unlock()
+		}
+	}
+	conn, err := p.get(ctx)
+	if err != nil {
+		p.releaseSlot()
+		return nil, err
+	}
+	return &Connection{c: conn.c, cfg: conn.cfg, stmts: conn.stmts, pool: p, base: conn}, nil
+}
+
+// get takes a healthy idle connection or opens a new one; the caller holds a slot.
+func (p *Pool) get(ctx context.Context) (*Connection, error) {
+	for {
+		p.mu.Lock()
+		if p.closed {
+			p.mu.Unlock()
+			return nil, ErrClosed
+		}
+		n := len(p.idle)
+		if n == 0 {
+			p.open++
+			p.stats.Acquires++
+			p.mu.Unlock()
+			conn, err := p.db.Conn(ctx)
+			if err != nil {
+				p.mu.Lock()
+				p.open--
+				p.stats.Acquires--
+				p.mu.Unlock()
+				return nil, err
+			}
+			conn.threads, _ = conn.MaxThreads()
+			return conn, nil
+		}
+		pc := p.idle[n-1]
+		p.idle[n-1] = nil
+		p.idle = p.idle[:n-1]
+		p.stats.Acquires++
+		p.mu.Unlock()
+
+		if time.Since(pc.idleSince) >= p.cfg.HealthCheckIdle {
+			if err := p.cfg.HealthCheck(ctx, pc.conn); err != nil {
+				if ctx.Err() != nil {
+					p.put(pc.conn)
+					p.mu.Lock()
+					p.stats.Acquires--
+					p.mu.Unlock()
+					return nil, ctx.Err()
+				}
+				pc.conn.Close()
+				p.mu.Lock()
+				p.open--
+				p.stats.Acquires--
+				p.stats.HealthCheckFailed++
+				p.mu.Unlock()
+				continue
+			}
+		}
+		return pc.conn, nil
+	}
+}
+
+// put returns conn to the idle list, or closes it when the pool is closed or full.
+func (p *Pool) put(conn *Connection) {
+	p.mu.Lock()
+	if p.closed || len(p.idle) >= p.cfg.MaxIdle {
+		p.open--
+		if !p.closed {
+			p.stats.IdleClosed++
+		}
+		p.mu.Unlock()
+		conn.Close()
+		return
+	}
+	p.idle = append(p.idle, &pooledConn{conn: conn, idleSince: time.Now()})
+	p.mu.Unlock()
+}
+
+func (p *Pool) release(conn *Connection) {
+	p.put(conn)
+	p.releaseSlot()
+}
+
+func (p *Pool) releaseSlot() {
+	if p.sem != nil {
+		<-p.sem
+	}
+}
+
+// reapIdle closes connections idle for longer than IdleTimeout until the pool is closed.
+func (p *Pool) reapIdle() {
+	t := time.NewTicker(max(p.cfg.IdleTimeout/2, time.Second))
+	defer t.Stop()
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-t.C:
+		}
+		var expired []*pooledConn
+		p.mu.Lock()
+		keep := p.idle[:0]
+		for _, pc := range p.idle {
+			if time.Since(pc.idleSince) >= p.cfg.IdleTimeout {
+				expired = append(expired, pc)
+			} else {
+				keep = append(keep, pc)
+			}
+		}
+		clear(p.idle[len(keep):])
+		p.idle = keep
+		p.open -= len(expired)
+		p.stats.IdleClosed += int64(len(expired))
+		p.mu.Unlock()
+		for _, pc := range expired {
+			pc.conn.Close()
+		}
+	}
+}
+
+// Stats returns the pool's current statistics.
+func (p *Pool) Stats() PoolStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	s := p.stats
+	s.MaxOpen = p.cfg.MaxOpen
+	s.Open = p.open
+	s.Idle = len(p.idle)
+	s.InUse = p.open - len(p.idle)
+	return s
+}
+
+// Close closes the idle connections and makes Acquire fail with ErrClosed. Connections
+// in use are closed when they are returned.
+func (p *Pool) Close() error {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil
+	}
+	p.closed = true
+	idle := p.idle
+	p.idle = nil
+	p.open -= len(idle)
+	p.mu.Unlock()
+	close(p.stop)
+	for _, pc := range idle {
+		pc.conn.Close()
+	}
+	return nil
+}
+
+// ping is the default PoolConfig.HealthCheck.
+func ping(ctx context.Context, conn *Connection) error {
+	res, err := conn.Query(ctx, "RETURN 1")
+	if err != nil {
+		return err
+	}
+	return res.Close()
+}
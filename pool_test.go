@@ -0,0 +1,153 @@
+package ladybug
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestPool(t *testing.T) {
+	ver, _ := Version()
+	if ver == "" {
+		t.Skip("Ladybug libs not available; skipping")
+	}
+	ctx := context.Background()
+	db, err := Open(ctx, filepath.Join(t.TempDir(), "testdb"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if _, err := NewPool(db, &PoolConfig{MaxOpen: -1}); err == nil {
+		t.Error("expected error for negative MaxOpen")
+	}
+	checks := 0
+	pool, err := NewPool(db, &PoolConfig{
+		MaxOpen: 2,
+		HealthCheck: func(ctx context.Context, conn *Connection) error {
+			checks++
+			return ping(ctx, conn)
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pool.Close()
+
+	c1, err := pool.Acquire(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c2, err := pool.Acquire(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := pool.Stats(); s.Open != 2 || s.InUse != 2 || s.Idle != 0 {
+		t.Errorf("stats = %+v, want 2 open and in use", s)
+	}
+	wctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	if _, err := pool.Acquire(wctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Acquire on full pool = %v, want deadline exceeded", err)
+	}
+	cancel()
+
+	if _, err := c1.BeginTx(ctx, TxOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	c1.SetQueryTimeout(time.Second)
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		c1.Close()
+	}()
+	c3, err := pool.Acquire(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c1.Query(ctx, "RETURN 1"); !errors.Is(err, ErrInvalidConn) {
+		t.Errorf("Query on released connection = %v, want ErrInvalidConn", err)
+	}
+	if checks != 1 {
+		t.Errorf("health checks = %d, want 1", checks)
+	}
+	// The transaction left open on c1 was rolled back, so c3 can start one.
+	tx, err := c3.BeginTx(ctx, TxOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = tx.Rollback()
+	c3.Close()
+	c2.Close()
+
+	s := pool.Stats()
+	if s.Open != 2 || s.Idle != 2 || s.InUse != 0 {
+		t.Errorf("stats = %+v, want 2 open and idle", s)
+	}
+	if s.Acquires != 3 || s.WaitCount != 1 {
+		t.Errorf("stats = %+v, want 3 acquires and 1 wait", s)
+	}
+	pool.Close()
+	if _, err := pool.Acquire(ctx); !errors.Is(err, ErrClosed) {
+		t.Errorf("Acquire after Close = %v, want ErrClosed", err)
+	}
+	if s := pool.Stats(); s.Open != 0 {
+		t.Errorf("open after Close = %d, want 0", s.Open)
+	}
+}
+
+func TestMaxThreads(t *testing.T) {
+	ver, _ := Version()
+	if ver == "" {
+		t.Skip("Ladybug libs not available; skipping")
+	}
+	ctx := context.Background()
+	db, err := Open(ctx, filepath.Join(t.TempDir(), "testdb"), &Config{MaxNumThreads: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	if n, err := conn.MaxThreads(); err != nil || n != 4 {
+		t.Fatalf("MaxThreads = %d, %v; want 4", n, err)
+	}
+	if err := conn.SetMaxThreads(0); err == nil {
+		t.Error("expected error for 0 threads")
+	}
+	if err := conn.SetMaxThreads(2); err != nil {
+		t.Fatal(err)
+	}
+	res, err := conn.Query(ctx, "RETURN 1", WithMaxThreads(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Close()
+	if n, _ := conn.MaxThreads(); n != 2 {
+		t.Errorf("MaxThreads after per-call option = %d, want 2", n)
+	}
+
+	pool, err := NewPool(db, &PoolConfig{MaxOpen: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pool.Close()
+	pc, err := pool.Acquire(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := pc.SetMaxThreads(1); err != nil {
+		t.Fatal(err)
+	}
+	pc.Close()
+	pc, err = pool.Acquire(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pc.Close()
+	if n, _ := pc.MaxThreads(); n != 4 {
+		t.Errorf("MaxThreads after pool reset = %d, want 4", n)
+	}
+}
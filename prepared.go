@@ -2,7 +2,8 @@ package ladybug
 
 import (
 	"context"
-	"fmt"
+	"maps"
+	"slices"
 	"time"
 
 	"github.com/vkozio/ladybug-go-zero/internal/lbugc"
@@ -13,6 +14,12 @@ type PreparedStatement struct {
 	c     *lbugc.PreparedStatement
 	conn  *Connection
 	query string
+	// cache, if set, takes the statement back on Close; gen is the cache generation at checkout.
+	cache *stmtCache
+	gen   uint64
+	// bound holds the parameters with a value bound in the C statement, including values
+	// left by earlier users of a cached statement. Only tracked when cache is set.
+	bound map[string]struct{}
 }
 
 // Close destroys the prepared statement.
@@ -20,20 +27,32 @@ func (ps *PreparedStatement) Close() error {
 	if ps == nil || ps.c == nil {
 		return nil
 	}
-	ps.c.Close()
+	if ps.cache != nil {
+		ps.cache.put(ps.query, ps.c, ps.gen, sortedNames(slices.Collect(maps.Keys(ps.bound))))
+	} else {
+		ps.c.Close()
+	}
 	ps.c = nil
 	ps.conn = nil
 	return nil
 }
 
+// noteBound records that name has a value bound, for the statement cache.
+func (ps *PreparedStatement) noteBound(name string) {
+	if ps.cache != nil {
+		ps.bound[name] = struct{}{}
+	}
+}
+
 // BindBool binds a bool parameter.
 func (ps *PreparedStatement) BindBool(name string, v bool) error {
 	if ps == nil || ps.c == nil {
 		return ErrClosed
 	}
 	if err := ps.c.BindBool(name, v); err != nil {
-		return fmt.Errorf("ladybug: %w", err)
+		return wrapErr(err)
 	}
+	ps.noteBound(name)
 	return nil
 }
 
@@ -43,8 +62,9 @@ func (ps *PreparedStatement) BindInt64(name string, v int64) error {
 		return ErrClosed
 	}
 	if err := ps.c.BindInt64(name, v); err != nil {
-		return fmt.Errorf("ladybug: %w", err)
+		return wrapErr(err)
 	}
+	ps.noteBound(name)
 	return nil
 }
 
@@ -54,8 +74,9 @@ func (ps *PreparedStatement) BindDouble(name string, v float64) error {
 		return ErrClosed
 	}
 	if err := ps.c.BindDouble(name, v); err != nil {
-		return fmt.Errorf("ladybug: %w", err)
+		return wrapErr(err)
 	}
+	ps.noteBound(name)
 	return nil
 }
 
@@ -65,19 +86,23 @@ func (ps *PreparedStatement) BindString(name string, v string) error {
 		return ErrClosed
 	}
 	if err := ps.c.BindString(name, v); err != nil {
-		return fmt.Errorf("ladybug: %w", err)
+		return wrapErr(err)
 	}
+	ps.noteBound(name)
 	return nil
 }
 
-// BindDate binds a date parameter (midnight UTC) from time.Time.
+// BindDate binds a date parameter from the calendar day of v in v's location. Use Bind
+// with a Date to bind a day directly.
 func (ps *PreparedStatement) BindDate(name string, v time.Time) error {
 	if ps == nil || ps.c == nil {
 		return ErrClosed
 	}
-	if err := ps.c.BindDate(name, v); err != nil {
-		return fmt.Errorf("ladybug: %w", err)
+	d := DateOf(v)
+	if err := ps.c.BindDate(name, d.Year, int(d.Month), d.Day); err != nil {
+		return wrapErr(err)
 	}
+	ps.noteBound(name)
 	return nil
 }
 
@@ -87,19 +112,22 @@ func (ps *PreparedStatement) BindTime(name string, v time.Time) error {
 		return ErrClosed
 	}
 	if err := ps.c.BindTime(name, v); err != nil {
-		return fmt.Errorf("ladybug: %w", err)
+		return wrapErr(err)
 	}
+	ps.noteBound(name)
 	return nil
 }
 
-// BindInterval binds an interval parameter from time.Duration.
+// BindInterval binds an interval parameter from time.Duration, as microseconds. Use Bind
+// with an Interval to bind months and days.
 func (ps *PreparedStatement) BindInterval(name string, v time.Duration) error {
 	if ps == nil || ps.c == nil {
 		return ErrClosed
 	}
-	if err := ps.c.BindInterval(name, v); err != nil {
-		return fmt.Errorf("ladybug: %w", err)
+	if err := ps.c.BindInterval(name, 0, 0, v.Microseconds()); err != nil {
+		return wrapErr(err)
 	}
+	ps.noteBound(name)
 	return nil
 }
 
@@ -109,13 +137,15 @@ func (ps *PreparedStatement) BindUUID(name string, v string) error {
 		return ErrClosed
 	}
 	if err := ps.c.BindUUID(name, v); err != nil {
-		return fmt.Errorf("ladybug: %w", err)
+		return wrapErr(err)
 	}
+	ps.noteBound(name)
 	return nil
 }
 
-// Execute runs the prepared statement and returns a Result. Caller must call Result.Close.
-func (ps *PreparedStatement) Execute(ctx context.Context) (*Result, error) {
+// Execute runs the prepared statement and returns a Result. opts apply to this call only.
+// Caller must call Result.Close.
+func (ps *PreparedStatement) Execute(ctx context.Context, opts ...QueryOption) (*Result, error) {
 	if ps == nil || ps.c == nil {
 		return nil, ErrClosed
 	}
@@ -125,7 +155,8 @@ func (ps *PreparedStatement) Execute(ctx context.Context) (*Result, error) {
 	if ctx != nil && ctx.Err() != nil {
 		return nil, ctx.Err()
 	}
-	if err := ps.conn.setTimeoutFromContext(ctx); err != nil {
+	ctx, o, restore, err := ps.conn.applyOptions(ctx, opts)
+	if err != nil {
 		return nil, err
 	}
 
@@ -139,18 +170,30 @@ func (ps *PreparedStatement) Execute(ctx context.Context) (*Result, error) {
 			}
 		}()
 	}
-	defer close(done)
+	// finish stops the interrupt watcher and releases the connection, so that hooks may
+	// use it.
+	finished := false
+	finish := func() {
+		if !finished {
+			finished = true
+			close(done)
+			restore()
+		}
+	}
+	defer finish()
 
 	res, err := ps.c.Execute(ps.conn.c)
 	if err != nil {
-		wrapped := fmt.Errorf("ladybug: %w", err)
+		wrapped := wrapCtxErr(ctx, err)
+		finish()
 		invokeQueryHook(ps.conn.cfg, ctx, ps.query, QuerySummary{}, wrapped)
 		return nil, wrapped
 	}
-	r := &Result{c: res}
+	r := &Result{c: res, chunkSize: o.chunkSize, loc: o.location}
 	if ctx != nil && ctx.Err() != nil {
 		r.Close()
 		errCtx := ctx.Err()
+		finish()
 		invokeQueryHook(ps.conn.cfg, ctx, ps.query, QuerySummary{}, errCtx)
 		return nil, errCtx
 	}
@@ -159,6 +202,8 @@ func (ps *PreparedStatement) Execute(ctx context.Context) (*Result, error) {
 	if s, err := r.Summary(); err == nil && s != nil {
 		summary = *s
 	}
+	ps.conn.invalidateStmtCache(ps.query)
+	finish()
 	invokeQueryHook(ps.conn.cfg, ctx, ps.query, summary, nil)
 	return r, nil
 }
@@ -0,0 +1,131 @@
+package ladybug
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// QueryAll runs cypher with params (nil for none) and opts, decodes every row of the result into a T
+// and closes the result. When T is a struct (or pointer to one) rows are decoded with the
+// rules of Row.ScanStruct; otherwise the result must have a single column, which is
+// converted to T as ScanStruct converts field values:
+//
+//	names, err := ladybug.QueryAll[string](ctx, conn, "MATCH (p:Person) RETURN p.name", nil)
+//	people, err := ladybug.QueryAll[Person](ctx, conn, "MATCH (p:Person) WHERE p.age > $min RETURN p", map[string]any{"min": 18})
+func QueryAll[T any](ctx context.Context, conn *Connection, cypher string, params map[string]any, opts ...QueryOption) ([]T, error) {
+	res, err := queryFor(ctx, conn, cypher, params, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	decode, err := rowDecoder[T](res)
+	if err != nil {
+		return nil, err
+	}
+	var out []T
+	for row, ok := res.Next(); ok; row, ok = res.Next() {
+		var v T
+		if err := decode(row, &v); err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	if err := res.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// QueryOne is QueryAll for queries expected to return exactly one row. It returns ErrNoRows
+// when the result is empty and ErrTooManyRows when it has more than one row.
+func QueryOne[T any](ctx context.Context, conn *Connection, cypher string, params map[string]any, opts ...QueryOption) (T, error) {
+	var zero T
+	res, err := queryFor(ctx, conn, cypher, params, opts)
+	if err != nil {
+		return zero, err
+	}
+	defer res.Close()
+	decode, err := rowDecoder[T](res)
+	if err != nil {
+		return zero, err
+	}
+	row, ok := res.Next()
+	if !ok {
+		if err := res.Err(); err != nil {
+			return zero, err
+		}
+		return zero, ErrNoRows
+	}
+	var v T
+	if err := decode(row, &v); err != nil {
+		return zero, err
+	}
+	if _, ok := res.Next(); ok {
+		return zero, ErrTooManyRows
+	}
+	if err := res.Err(); err != nil {
+		return zero, err
+	}
+	return v, nil
+}
+
+func queryFor(ctx context.Context, conn *Connection, cypher string, params map[string]any, opts []QueryOption) (*Result, error) {
+	if len(params) == 0 {
+		return conn.Query(ctx, cypher, opts...)
+	}
+	return conn.QueryParams(ctx, cypher, params, opts...)
+}
+
+// rowDecoder returns a function decoding a row of res into a T.
+func rowDecoder[T any](res *Result) (func(Row, *T) error, error) {
+	t := reflect.TypeFor[T]()
+	st := t
+	if st.Kind() == reflect.Pointer {
+		st = st.Elem()
+	}
+	if decodesAsStruct(st) {
+		plan, err := res.scanPlan(st)
+		if err != nil {
+			return nil, err
+		}
+		return func(row Row, dst *T) error {
+			v := reflect.ValueOf(dst).Elem()
+			if v.Kind() == reflect.Pointer {
+				v.Set(reflect.New(st))
+				v = v.Elem()
+			}
+			return row.scanInto(v, plan)
+		}, nil
+	}
+	cols, err := res.Columns()
+	if err != nil {
+		return nil, err
+	}
+	if len(cols) != 1 {
+		return nil, fmt.Errorf("ladybug: cannot decode %d columns into %s; use a struct type", len(cols), t)
+	}
+	typed := isTimestampType(t)
+	return func(row Row, dst *T) error {
+		v, err := row.value(0, typed)
+		if err != nil {
+			return err
+		}
+		if err := assignValue(reflect.ValueOf(dst).Elem(), v); err != nil {
+			return fmt.Errorf("ladybug: column %q: %w", cols[0].Name, err)
+		}
+		return nil
+	}, nil
+}
+
+// decodesAsStruct reports whether rows decode into t field by field rather than as one value.
+func decodesAsStruct(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	switch t {
+	case timeType, nodeType, relType, pathType, idType, bigIntType:
+		return false
+	}
+	return !reflect.PointerTo(t).Implements(scannerType)
+}
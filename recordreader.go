@@ -0,0 +1,122 @@
+package ladybug
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/cdata"
+)
+
+var _ array.RecordReader = (*recordReader)(nil)
+
+// recordReader adapts a Result's Arrow path to array.RecordReader.
+type recordReader struct {
+	refs      atomic.Int64
+	res       *Result
+	chunkSize int64
+	schema    *arrow.Schema
+	cur       arrow.Record
+	err       error
+	// ownsResult closes res when the last reference is released (ExportArrowStream).
+	ownsResult bool
+}
+
+// RecordReader returns an array.RecordReader over the remaining rows of the current result
+// set, in records of up to chunkSize rows (0 = DefaultArrowChunkSize). It can be passed to
+// any arrow-go consumer such as ipc.NewWriter or pqarrow. The reader starts with one
+// reference; call Release when done. The Result must stay open while the reader is in use
+// and must not be iterated by other means meanwhile.
+func (r *Result) RecordReader(chunkSize int64) (array.RecordReader, error) {
+	return r.newRecordReader(chunkSize)
+}
+
+func (r *Result) newRecordReader(chunkSize int64) (*recordReader, error) {
+	if r == nil || r.c == nil {
+		return nil, ErrClosed
+	}
+	sc := r.Schema()
+	if sc == nil {
+		return nil, fmt.Errorf("ladybug: no schema")
+	}
+	rr := &recordReader{res: r, chunkSize: chunkSize, schema: sc}
+	rr.refs.Store(1)
+	return rr, nil
+}
+
+// ExportArrowStream exports the remaining rows as a C ArrowArrayStream, so other CGO code in
+// the process can pull record batches through the Arrow C stream interface. out must be
+// zero-initialized C memory (see cdata.ExportRecordReader). The stream takes ownership of the
+// Result: it is closed when the consumer calls the stream's release callback, and must not be
+// used or closed by the caller afterwards.
+func (r *Result) ExportArrowStream(chunkSize int64, out *cdata.CArrowArrayStream) error {
+	rr, err := r.newRecordReader(chunkSize)
+	if err != nil {
+		return err
+	}
+	rr.ownsResult = true
+	cdata.ExportRecordReader(rr, out)
+	// The stream holds its own reference; drop ours so its release closes the Result.
+	rr.Release()
+	return nil
+}
+
+func (rr *recordReader) Retain() {
+	rr.refs.Add(1)
+}
+
+func (rr *recordReader) Release() {
+	if rr.refs.Add(-1) != 0 {
+		return
+	}
+	if rr.cur != nil {
+		rr.cur.Release()
+		rr.cur = nil
+	}
+	if rr.ownsResult {
+		rr.res.Close()
+	}
+}
+
+func (rr *recordReader) Schema() *arrow.Schema {
+	return rr.schema
+}
+
+// Next advances to the next record, releasing the previous one.
+func (rr *recordReader) Next() bool {
+	if rr.cur != nil {
+		rr.cur.Release()
+		rr.cur = nil
+	}
+	if rr.err != nil {
+		return false
+	}
+	rec, err := rr.res.NextRecord(rr.chunkSize)
+	if err != nil {
+		rr.err = err
+		return false
+	}
+	if rec == nil {
+		return false
+	}
+	rr.cur = rec
+	return true
+}
+
+// RecordBatch returns the current record. It is valid until the next call to Next; call
+// Retain to keep it longer.
+func (rr *recordReader) RecordBatch() arrow.RecordBatch {
+	return rr.cur
+}
+
+// Record returns the current record.
+//
+// Deprecated: Use RecordBatch instead.
+func (rr *recordReader) Record() arrow.RecordBatch {
+	return rr.cur
+}
+
+func (rr *recordReader) Err() error {
+	return rr.err
+}
@@ -0,0 +1,131 @@
+package ladybug
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"path/filepath"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow/cdata"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+)
+
+func TestResultRecordReader(t *testing.T) {
+	ver, _ := Version()
+	if ver == "" {
+		t.Skip("Ladybug libs not available; skipping")
+	}
+	ctx := context.Background()
+	db, err := Open(ctx, filepath.Join(t.TempDir(), "testdb"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	res, err := conn.Query(ctx, "UNWIND range(1, 10) AS x RETURN x, CAST(x AS STRING) AS s")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Close()
+	rr, err := res.RecordReader(4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rr.Release()
+
+	// Round-trip through an IPC stream to check interoperability with arrow-go consumers.
+	var buf bytes.Buffer
+	w := ipc.NewWriter(&buf, ipc.WithSchema(rr.Schema()))
+	for rr.Next() {
+		if err := w.Write(rr.RecordBatch()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := rr.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	r, err := ipc.NewReader(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Release()
+	var rows int64
+	for r.Next() {
+		rows += r.RecordBatch().NumRows()
+	}
+	if rows != 10 {
+		t.Errorf("read back %d rows, want 10", rows)
+	}
+}
+
+func TestExportArrowStream(t *testing.T) {
+	ver, _ := Version()
+	if ver == "" {
+		t.Skip("Ladybug libs not available; skipping")
+	}
+	ctx := context.Background()
+	db, err := Open(ctx, filepath.Join(t.TempDir(), "testdb"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	export := func() (*Result, *cdata.CArrowArrayStream) {
+		t.Helper()
+		res, err := conn.Query(ctx, "UNWIND range(1, 10) AS x RETURN x")
+		if err != nil {
+			t.Fatal(err)
+		}
+		stream := new(cdata.CArrowArrayStream)
+		if err := res.ExportArrowStream(4, stream); err != nil {
+			res.Close()
+			t.Fatal(err)
+		}
+		return res, stream
+	}
+
+	// Drain the stream as a C consumer would.
+	_, stream := export()
+	rdr, err := cdata.ImportCRecordReader(stream, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rows int64
+	for {
+		rec, err := rdr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		rows += rec.NumRows()
+	}
+	if rows != 10 {
+		t.Errorf("read %d rows, want 10", rows)
+	}
+
+	// The imported reader only calls the release callback from a finalizer, so call it
+	// directly to check that it closes the Result.
+	res, stream := export()
+	if res.c == nil {
+		t.Fatal("Result closed before the stream was released")
+	}
+	cdata.ReleaseCArrowArrayStream(stream)
+	if res.c != nil {
+		t.Error("releasing the stream did not close the Result")
+	}
+}
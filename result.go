@@ -2,6 +2,7 @@ package ladybug
 
 import (
 	"fmt"
+	"math/big"
 	"time"
 
 	"github.com/apache/arrow-go/v18/arrow"
@@ -13,10 +14,32 @@ import (
 const DefaultArrowChunkSize = 64 * 1024
 
 // Result holds the result of a Cypher query. Call Close when done.
+// For a multi-statement query, Result starts at the first statement's result;
+// use NextResultSet to advance to the following ones.
 type Result struct {
-	c       *lbugc.Result
+	c *lbugc.Result
+	// head is the first result of a multi-statement chain once NextResultSet has advanced
+	// past it. It owns every later result and is destroyed last.
+	head    *lbugc.Result
 	schema  *arrow.Schema
+	columns []Column
 	lastRow *lbugc.Row
+	// err is the first error hit while iterating rows or records; reported by Err.
+	err error
+	// stmt is closed with the result when it was prepared just for this query (QueryParams).
+	stmt *PreparedStatement
+	// chunkSize is the record size set with WithArrowChunkSize (0 = DefaultArrowChunkSize).
+	chunkSize int64
+	// loc is the location timestamps are rendered in (nil = UTC).
+	loc *time.Location
+}
+
+// location returns the location r renders timestamps in.
+func (r *Result) location() *time.Location {
+	if r == nil || r.loc == nil {
+		return time.UTC
+	}
+	return r.loc
 }
 
 // Close releases the result and any Arrow schema. Call after consuming rows/records.
@@ -30,17 +53,74 @@ func (r *Result) Close() error {
 	}
 	r.c.Close()
 	r.c = nil
+	if r.head != nil {
+		r.head.Close()
+		r.head = nil
+	}
+	if r.stmt != nil {
+		r.stmt.Close()
+		r.stmt = nil
+	}
 	r.schema = nil
+	r.columns = nil
 	return nil
 }
 
-// Err returns the query error if the result indicates failure (e.g. after Query).
-func (r *Result) Err() error {
+// HasNextResultSet reports whether a multi-statement query has another statement result.
+func (r *Result) HasNextResultSet() bool {
+	if r == nil || r.c == nil {
+		return false
+	}
+	return r.c.HasNextResult()
+}
+
+// NextResultSet advances to the next statement's result, discarding any unread rows of the
+// current one. It returns false when there are no more results, and an error if the next
+// statement failed. Schema, Next, NextRecord and Summary then refer to the new result.
+func (r *Result) NextResultSet() (bool, error) {
 	if r == nil || r.c == nil {
+		return false, ErrClosed
+	}
+	next, err := r.c.NextResult()
+	if err != nil {
+		return false, r.setErr(wrapErr(err))
+	}
+	if next == nil {
+		return false, nil
+	}
+	if r.lastRow != nil {
+		r.lastRow.Release()
+		r.lastRow = nil
+	}
+	if r.head == nil {
+		r.head = r.c
+	} else {
+		r.c.Close()
+	}
+	r.c = next
+	r.schema = nil
+	r.columns = nil
+	return true, nil
+}
+
+// Err returns the first error encountered while iterating: fetching a tuple in Next,
+// converting a value in Row.Value (and the accessors built on it), importing a chunk in
+// NextRecord, or advancing with NextResultSet. Check it after the Next loop ends, as
+// with bufio.Scanner or sql.Rows; Next returning false alone does not mean success.
+// Err remains valid after Close.
+func (r *Result) Err() error {
+	if r == nil {
 		return nil
 	}
-	// Result was already checked for success in Query; no per-result error message exposed here.
-	return nil
+	return r.err
+}
+
+// setErr records err as the Result's error if none is recorded yet, and returns it.
+func (r *Result) setErr(err error) error {
+	if r != nil && r.err == nil {
+		r.err = err
+	}
+	return err
 }
 
 // QuerySummary contains basic timing information for a query.
@@ -56,7 +136,7 @@ func (r *Result) Summary() (*QuerySummary, error) {
 	}
 	compile, exec, err := r.c.Summary()
 	if err != nil {
-		return nil, fmt.Errorf("ladybug: %w", err)
+		return nil, wrapErr(err)
 	}
 	return &QuerySummary{
 		CompileMS: compile,
@@ -88,23 +168,31 @@ func (r *Result) Schema() *arrow.Schema {
 	return r.schema
 }
 
-// NextRecord returns the next Arrow record batch (chunkSize rows; 0 = DefaultArrowChunkSize).
+// NextRecord returns the next Arrow record batch (chunkSize rows; 0 = the query's
+// WithArrowChunkSize, or DefaultArrowChunkSize).
 // Caller must call record.Release() when done.
 // Returns (nil, nil) when there are no more records.
 func (r *Result) NextRecord(chunkSize int64) (arrow.Record, error) {
 	if r == nil || r.c == nil {
 		return nil, nil
 	}
+	if chunkSize <= 0 {
+		chunkSize = r.chunkSize
+	}
 	if chunkSize <= 0 {
 		chunkSize = DefaultArrowChunkSize
 	}
 	sc := r.Schema()
 	if sc == nil {
-		return nil, fmt.Errorf("ladybug: no schema")
+		return nil, r.setErr(fmt.Errorf("ladybug: no schema"))
+	}
+	// The library returns empty chunks, not an end marker, once the rows are exhausted.
+	if !r.c.HasNext() {
+		return nil, nil
 	}
 	arrayPtr, cleanup, releaseAndFree, err := r.c.NextChunkRaw(chunkSize)
 	if err != nil {
-		return nil, fmt.Errorf("ladybug: %w", err)
+		return nil, r.setErr(wrapErr(err))
 	}
 	if arrayPtr == nil {
 		return nil, nil
@@ -114,42 +202,103 @@ func (r *Result) NextRecord(chunkSize int64) (arrow.Record, error) {
 	rec, err := cdata.ImportCRecordBatchWithSchema((*cdata.CArrowArray)(arrayPtr), sc)
 	if err != nil {
 		releaseAndFree()
-		return nil, fmt.Errorf("ladybug: %w", err)
+		return nil, r.setErr(fmt.Errorf("ladybug: import arrow record: %w", err))
 	}
 	cleanup()
 	return rec, nil
 }
 
 // Next returns the next row. The returned Row is valid until the next call to Next or Close.
+// Next returns false both at the end of the rows and on error; check Err after the loop.
 // Arrow iteration is preferred for bulk; use NextRecord for better performance.
 func (r *Result) Next() (Row, bool) {
 	if r == nil || r.c == nil {
 		return Row{}, false
 	}
+	row, ok, err := r.next()
+	if err != nil {
+		r.setErr(err)
+	}
+	return row, ok
+}
+
+// next is Next reporting the fetch error directly.
+func (r *Result) next() (Row, bool, error) {
 	if r.lastRow != nil {
 		r.lastRow.Release()
 		r.lastRow = nil
 	}
-	row, ok, _ := r.c.GetNext()
+	row, ok, err := r.c.GetNext()
+	if err != nil {
+		return Row{}, false, wrapErr(err)
+	}
 	if !ok || row == nil {
-		return Row{}, false
+		return Row{}, false, nil
 	}
 	r.lastRow = row
-	return Row{c: row, numCols: r.c.NumColumns()}, true
+	return Row{c: row, numCols: r.c.NumColumns(), res: r}, true, nil
 }
 
 // Row represents one result row. Do not retain; only use until next Next() or Result.Close().
 type Row struct {
 	c       *lbugc.Row
 	numCols uint64
+	// res receives conversion errors so that Result.Err reports them.
+	res *Result
 }
 
 // Value returns the value at column index (0-based). Returns nil for NULL.
+// Timestamps are returned as time.Time in the query's location (Config.Location or
+// WithLocation, UTC by default) and dates as Date.
 func (row Row) Value(index uint64) (interface{}, error) {
+	return row.value(index, false)
+}
+
+// value is Value returning timestamps as Timestamp when typed is set.
+func (row Row) value(index uint64, typed bool) (any, error) {
 	if row.c == nil {
 		return nil, ErrClosed
 	}
-	return row.c.Value(index)
+	v, err := row.c.Value(index)
+	if err != nil {
+		return nil, row.res.setErr(wrapErr(err))
+	}
+	return fromDriver(v, row.res.location(), typed), nil
+}
+
+// fromDriver replaces the internal package's value types in v (as returned by lbugc.Row.Value)
+// with their exported counterparts. Timestamps are rendered in loc, as Timestamp if typed is
+// set and as time.Time otherwise.
+func fromDriver(v any, loc *time.Location, typed bool) any {
+	switch x := v.(type) {
+	case lbugc.Timestamp:
+		ts := timestampFromDriver(x, loc)
+		if typed {
+			return ts
+		}
+		return ts.Time
+	case lbugc.Date:
+		return dateFromDays(int64(x))
+	case lbugc.InternalID:
+		return InternalID{Table: x.Table, Offset: x.Offset}
+	case lbugc.Interval:
+		return Interval{Months: x.Months, Days: x.Days, Micros: x.Micros}
+	case lbugc.Decimal:
+		d, err := ParseDecimal(string(x))
+		if err != nil {
+			return string(x)
+		}
+		return d
+	case []any:
+		for i, e := range x {
+			x[i] = fromDriver(e, loc, typed)
+		}
+	case map[string]any:
+		for k, e := range x {
+			x[k] = fromDriver(e, loc, typed)
+		}
+	}
+	return v
 }
 
 // NumColumns returns the number of columns in this row.
@@ -235,22 +384,46 @@ func (row Row) Bytes(index int) ([]byte, error) {
 	return b, nil
 }
 
-// Time returns the time.Time value at column index (for timestamps and dates).
+// Time returns the time.Time value at column index. Timestamps are in the query's location;
+// dates are midnight UTC.
 func (row Row) Time(index int) (time.Time, error) {
 	v, err := row.Value(uint64(index))
 	if err != nil {
 		return time.Time{}, err
 	}
-	t, ok := v.(time.Time)
+	switch t := v.(type) {
+	case time.Time:
+		return t, nil
+	case Date:
+		return t.In(time.UTC), nil
+	}
+	return time.Time{}, fmt.Errorf("ladybug: column %d is not time.Time (got %T)", index, v)
+}
+
+// Date returns the DATE value at column index.
+func (row Row) Date(index int) (Date, error) {
+	v, err := row.Value(uint64(index))
+	if err != nil {
+		return Date{}, err
+	}
+	d, ok := v.(Date)
 	if !ok {
-		return time.Time{}, fmt.Errorf("ladybug: column %d is not time.Time (got %T)", index, v)
+		return Date{}, fmt.Errorf("ladybug: column %d is not Date (got %T)", index, v)
 	}
-	return t, nil
+	return d, nil
 }
 
-// Date is an alias for Time, provided for clarity when working with DATE columns.
-func (row Row) Date(index int) (time.Time, error) {
-	return row.Time(index)
+// Timestamp returns the timestamp value at column index with its precision.
+func (row Row) Timestamp(index int) (Timestamp, error) {
+	v, err := row.value(uint64(index), true)
+	if err != nil {
+		return Timestamp{}, err
+	}
+	ts, ok := v.(Timestamp)
+	if !ok {
+		return Timestamp{}, fmt.Errorf("ladybug: column %d is not a timestamp (got %T)", index, v)
+	}
+	return ts, nil
 }
 
 // UUID returns the UUID value at column index as string.
@@ -260,16 +433,16 @@ func (row Row) UUID(index int) (string, error) {
 
 // Node represents a graph node value.
 type Node struct {
-	ID         any
+	ID         InternalID
 	Labels     []string
 	Properties map[string]any
 }
 
 // Rel represents a graph relationship value.
 type Rel struct {
-	ID         any
-	SrcID      any
-	DstID      any
+	ID         InternalID
+	SrcID      InternalID
+	DstID      InternalID
 	Label      string
 	Properties map[string]any
 }
@@ -298,7 +471,7 @@ func AsNode(v any) (Node, bool) {
 	if !ok {
 		return Node{}, false
 	}
-	id, _ := m["id"]
+	id, _ := asInternalID(m["id"])
 	rawLabels, _ := m["labels"]
 	propsAny, _ := m["properties"]
 
@@ -332,9 +505,9 @@ func AsRel(v any) (Rel, bool) {
 	if !ok {
 		return Rel{}, false
 	}
-	id, _ := m["id"]
-	src, _ := m["src_id"]
-	dst, _ := m["dst_id"]
+	id, _ := asInternalID(m["id"])
+	src, _ := asInternalID(m["src_id"])
+	dst, _ := asInternalID(m["dst_id"])
 	label, _ := m["label"].(string)
 	propsAny, _ := m["properties"]
 	props, _ := propsAny.(map[string]any)
@@ -394,7 +567,8 @@ func (row Row) Scan(dest ...any) error {
 		if d == nil {
 			return fmt.Errorf("ladybug: Scan dest[%d] is nil", i)
 		}
-		v, err := row.Value(uint64(i))
+		_, typed := d.(*Timestamp)
+		v, err := row.value(uint64(i), typed)
 		if err != nil {
 			return err
 		}
@@ -446,17 +620,35 @@ func (row Row) Scan(dest ...any) error {
 			}
 			*ptr = val
 		case *time.Time:
-			val, ok := v.(time.Time)
-			if !ok {
+			switch val := v.(type) {
+			case time.Time:
+				*ptr = val
+			case Date:
+				*ptr = val.In(time.UTC)
+			default:
 				return fmt.Errorf("ladybug: column %d is not assignable to *time.Time (got %T)", i, v)
 			}
-			*ptr = val
+		case *Date:
+			if err := ptr.Scan(v); err != nil {
+				return fmt.Errorf("ladybug: column %d is not assignable to *Date (got %T)", i, v)
+			}
+		case *Timestamp:
+			if err := ptr.Scan(v); err != nil {
+				return fmt.Errorf("ladybug: column %d is not assignable to *Timestamp (got %T)", i, v)
+			}
 		case *time.Duration:
-			val, ok := v.(time.Duration)
-			if !ok {
+			switch val := v.(type) {
+			case Interval:
+				*ptr = val.ApproxDuration()
+			case time.Duration:
+				*ptr = val
+			default:
 				return fmt.Errorf("ladybug: column %d is not assignable to *time.Duration (got %T)", i, v)
 			}
-			*ptr = val
+		case *Interval:
+			if err := ptr.Scan(v); err != nil {
+				return fmt.Errorf("ladybug: column %d is not assignable to *Interval (got %T)", i, v)
+			}
 		case *Node:
 			n, ok := AsNode(v)
 			if !ok {
@@ -469,6 +661,28 @@ func (row Row) Scan(dest ...any) error {
 				return fmt.Errorf("ladybug: column %d is not assignable to *Rel (got %T)", i, v)
 			}
 			*ptr = r
+		case *Decimal:
+			if err := ptr.Scan(v); err != nil {
+				return fmt.Errorf("ladybug: column %d is not assignable to *Decimal (got %T)", i, v)
+			}
+		case *big.Int:
+			n, ok := bigIntOf(v)
+			if !ok {
+				return fmt.Errorf("ladybug: column %d is not assignable to *big.Int (got %T)", i, v)
+			}
+			ptr.Set(n)
+		case *InternalID:
+			id, ok := v.(InternalID)
+			if !ok {
+				return fmt.Errorf("ladybug: column %d is not assignable to *InternalID (got %T)", i, v)
+			}
+			*ptr = id
+		case *Path:
+			p, ok := AsPath(v)
+			if !ok {
+				return fmt.Errorf("ladybug: column %d is not assignable to *Path (got %T)", i, v)
+			}
+			*ptr = p
 		case *[]any:
 			val, ok := v.([]any)
 			if !ok {
@@ -0,0 +1,450 @@
+package ladybug
+
+import (
+	"database/sql"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ScanStruct copies the row into the struct pointed to by dest, matching result column names
+// against field names. A field's name is its `lbug:"name"` tag, or the field name compared
+// case-insensitively when no field has the exact name. Fields of embedded structs are
+// promoted; columns without a matching field are ignored, as are fields without a column.
+//
+// Values are converted to the field's type: integers and floats to any numeric width that
+// holds them, LISTs to slices and arrays, STRUCTs, MAPs and the properties of NODE and REL
+// values to nested structs or maps. NULL sets the field to its zero value, so use a pointer
+// field to tell NULL apart. Fields of type Node, Rel, Path, any and sql.Scanner implementations are
+// also supported.
+//
+// When the result has a single NODE, REL or STRUCT column that matches no field, the
+// column's properties are scanned into dest itself:
+//
+//	var p Person
+//	err := row.ScanStruct(&p) // MATCH (p:Person) RETURN p
+func (row Row) ScanStruct(dest any) error {
+	if row.c == nil {
+		return ErrClosed
+	}
+	rv := reflect.ValueOf(dest)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("ladybug: ScanStruct dest must be a non-nil pointer to a struct (got %T)", dest)
+	}
+	plan, err := row.res.scanPlan(rv.Elem().Type())
+	if err != nil {
+		return err
+	}
+	return row.scanInto(rv.Elem(), plan)
+}
+
+// ScanAll reads the remaining rows of the current result set into dest, which must point to
+// a slice of structs or of pointers to structs. Rows are appended with the rules of
+// Row.ScanStruct. Iteration errors are returned as reported by Err.
+func (r *Result) ScanAll(dest any) error {
+	if r == nil || r.c == nil {
+		return ErrClosed
+	}
+	rv := reflect.ValueOf(dest)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("ladybug: ScanAll dest must be a non-nil pointer to a slice (got %T)", dest)
+	}
+	slice := rv.Elem()
+	elemType := slice.Type().Elem()
+	structType, isPtr := elemType, false
+	if structType.Kind() == reflect.Pointer {
+		structType, isPtr = structType.Elem(), true
+	}
+	if structType.Kind() != reflect.Struct {
+		return fmt.Errorf("ladybug: ScanAll dest must be a slice of structs (got %T)", dest)
+	}
+	plan, err := r.scanPlan(structType)
+	if err != nil {
+		return err
+	}
+	for row, ok := r.Next(); ok; row, ok = r.Next() {
+		elem := reflect.New(structType)
+		if err := row.scanInto(elem.Elem(), plan); err != nil {
+			return r.setErr(err)
+		}
+		if isPtr {
+			slice.Set(reflect.Append(slice, elem))
+		} else {
+			slice.Set(reflect.Append(slice, elem.Elem()))
+		}
+	}
+	return r.Err()
+}
+
+// scanPlan maps columns to struct fields. whole is set when the single column is to be
+// decoded into the struct itself.
+type scanPlan struct {
+	fields []*structField // indexed by column; nil for unmatched columns
+	names  []string
+	whole  bool
+}
+
+func (r *Result) scanPlan(t reflect.Type) (*scanPlan, error) {
+	cols, err := r.Columns()
+	if err != nil {
+		return nil, err
+	}
+	fields := cachedStructFields(t)
+	p := &scanPlan{fields: make([]*structField, len(cols)), names: make([]string, len(cols))}
+	matched := false
+	for i, col := range cols {
+		p.names[i] = col.Name
+		if f := matchField(fields, col.Name); f != nil {
+			p.fields[i] = f
+			matched = true
+		}
+	}
+	if !matched && len(cols) == 1 {
+		switch cols[0].Type.ID {
+		case TypeNode, TypeRel, TypeStruct:
+			p.whole = true
+		}
+	}
+	return p, nil
+}
+
+// matchField returns the field named name, or else the first field whose name matches it
+// case-insensitively.
+func matchField(fields []structField, name string) *structField {
+	for i := range fields {
+		if fields[i].name == name {
+			return &fields[i]
+		}
+	}
+	for i := range fields {
+		if strings.EqualFold(fields[i].name, name) {
+			return &fields[i]
+		}
+	}
+	return nil
+}
+
+func (row Row) scanInto(dst reflect.Value, p *scanPlan) error {
+	if p.whole {
+		v, err := row.Value(0)
+		if err != nil {
+			return err
+		}
+		if err := assignValue(dst, v); err != nil {
+			return fmt.Errorf("ladybug: column %q: %w", p.names[0], err)
+		}
+		return nil
+	}
+	for i, f := range p.fields {
+		if f == nil {
+			continue
+		}
+		v, err := row.value(uint64(i), isTimestampType(f.typ))
+		if err != nil {
+			return err
+		}
+		fv, err := fieldByIndexAlloc(dst, f.index)
+		if err == nil {
+			err = assignValue(fv, v)
+		}
+		if err != nil {
+			return fmt.Errorf("ladybug: column %q into field %s: %w", p.names[i], f.name, err)
+		}
+	}
+	return nil
+}
+
+var structFieldsCache sync.Map // reflect.Type -> []structField
+
+// cachedStructFields is structFields memoized per type.
+func cachedStructFields(t reflect.Type) []structField {
+	if fs, ok := structFieldsCache.Load(t); ok {
+		return fs.([]structField)
+	}
+	fs, _ := structFieldsCache.LoadOrStore(t, structFields(t))
+	return fs.([]structField)
+}
+
+// fieldByIndexAlloc is reflect.Value.FieldByIndex that allocates nil embedded pointers. As in
+// encoding/json, a nil pointer to an unexported embedded struct cannot be allocated and is
+// an error.
+func fieldByIndexAlloc(v reflect.Value, index []int) (reflect.Value, error) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				if !v.CanSet() {
+					return reflect.Value{}, fmt.Errorf("cannot set embedded pointer to unexported struct %s", v.Type().Elem())
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, nil
+}
+
+var (
+	nodeType      = reflect.TypeOf(Node{})
+	relType       = reflect.TypeOf(Rel{})
+	pathType      = reflect.TypeOf(Path{})
+	dateType      = reflect.TypeOf(Date{})
+	timestampType = reflect.TypeOf(Timestamp{})
+	scannerType   = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+)
+
+// isTimestampType reports whether t is Timestamp or *Timestamp, which are decoded from
+// Row.value with typed set so that the precision is kept.
+func isTimestampType(t reflect.Type) bool {
+	return t == timestampType || t.Kind() == reflect.Pointer && t.Elem() == timestampType
+}
+
+// assignValue stores the driver value v (as returned by Row.Value) into dst.
+func assignValue(dst reflect.Value, v any) error {
+	if dst.CanAddr() && dst.Addr().Type().Implements(scannerType) {
+		return dst.Addr().Interface().(sql.Scanner).Scan(v)
+	}
+	if v == nil {
+		dst.SetZero()
+		return nil
+	}
+	switch dst.Kind() {
+	case reflect.Pointer:
+		p := reflect.New(dst.Type().Elem())
+		if err := assignValue(p.Elem(), v); err != nil {
+			return err
+		}
+		dst.Set(p)
+		return nil
+	case reflect.Interface:
+		rv := reflect.ValueOf(v)
+		if !rv.Type().AssignableTo(dst.Type()) {
+			return fmt.Errorf("cannot assign %T to %s", v, dst.Type())
+		}
+		dst.Set(rv)
+		return nil
+	}
+	switch dst.Type() {
+	case nodeType:
+		n, ok := AsNode(v)
+		if !ok {
+			return fmt.Errorf("cannot assign %T to Node", v)
+		}
+		dst.Set(reflect.ValueOf(n))
+		return nil
+	case relType:
+		r, ok := AsRel(v)
+		if !ok {
+			return fmt.Errorf("cannot assign %T to Rel", v)
+		}
+		dst.Set(reflect.ValueOf(r))
+		return nil
+	case pathType:
+		p, ok := AsPath(v)
+		if !ok {
+			return fmt.Errorf("cannot assign %T to Path", v)
+		}
+		dst.Set(reflect.ValueOf(p))
+		return nil
+	case bigIntType:
+		i, ok := bigIntOf(v)
+		if !ok || !dst.CanAddr() {
+			return fmt.Errorf("cannot assign %T to big.Int", v)
+		}
+		dst.Addr().Interface().(*big.Int).Set(i)
+		return nil
+	case durationType:
+		if iv, ok := v.(Interval); ok {
+			dst.SetInt(int64(iv.ApproxDuration()))
+			return nil
+		}
+		fallthrough
+	case timeType, idType:
+		if d, ok := v.(Date); ok && dst.Type() == timeType {
+			dst.Set(reflect.ValueOf(d.In(time.UTC)))
+			return nil
+		}
+		rv := reflect.ValueOf(v)
+		if !rv.Type().AssignableTo(dst.Type()) {
+			return fmt.Errorf("cannot assign %T to %s", v, dst.Type())
+		}
+		dst.Set(rv)
+		return nil
+	}
+
+	switch src := v.(type) {
+	case int64:
+		return assignInt(dst, src)
+	case uint64:
+		return assignUint64(dst, src)
+	case float64:
+		switch dst.Kind() {
+		case reflect.Float32, reflect.Float64:
+			dst.SetFloat(src)
+			return nil
+		}
+	case bool:
+		if dst.Kind() == reflect.Bool {
+			dst.SetBool(src)
+			return nil
+		}
+	case string:
+		if dst.Kind() == reflect.String {
+			dst.SetString(src)
+			return nil
+		}
+	case *big.Int:
+		if src.IsInt64() {
+			return assignInt(dst, src.Int64())
+		}
+		if src.IsUint64() {
+			return assignUint64(dst, src.Uint64())
+		}
+		if isFloatKind(dst.Kind()) {
+			f, _ := new(big.Float).SetInt(src).Float64()
+			dst.SetFloat(f)
+			return nil
+		}
+		return fmt.Errorf("value %s overflows %s", src, dst.Type())
+	case Decimal:
+		if isFloatKind(dst.Kind()) {
+			dst.SetFloat(src.Float64())
+			return nil
+		}
+		if i, ok := src.Int(); ok && (isIntKind(dst.Kind()) || isUintKind(dst.Kind())) {
+			return assignValue(dst, i)
+		}
+		if dst.Kind() == reflect.String {
+			dst.SetString(src.String())
+			return nil
+		}
+	case []byte:
+		if dst.Kind() == reflect.Slice && dst.Type().Elem().Kind() == reflect.Uint8 {
+			dst.SetBytes(append([]byte(nil), src...))
+			return nil
+		}
+	case []any:
+		return assignList(dst, src)
+	case map[string]any:
+		return assignMap(dst, src)
+	}
+	return fmt.Errorf("cannot assign %T to %s", v, dst.Type())
+}
+
+func assignInt(dst reflect.Value, i int64) error {
+	switch k := dst.Kind(); {
+	case k >= reflect.Int && k <= reflect.Int64:
+		if dst.OverflowInt(i) {
+			return fmt.Errorf("value %d overflows %s", i, dst.Type())
+		}
+		dst.SetInt(i)
+	case k >= reflect.Uint && k <= reflect.Uintptr:
+		if i < 0 {
+			return fmt.Errorf("value %d overflows %s", i, dst.Type())
+		}
+		return assignUint(dst, uint64(i))
+	case k == reflect.Float32 || k == reflect.Float64:
+		dst.SetFloat(float64(i))
+	default:
+		return fmt.Errorf("cannot assign integer to %s", dst.Type())
+	}
+	return nil
+}
+
+func assignUint(dst reflect.Value, u uint64) error {
+	if dst.OverflowUint(u) {
+		return fmt.Errorf("value %d overflows %s", u, dst.Type())
+	}
+	dst.SetUint(u)
+	return nil
+}
+
+func assignList(dst reflect.Value, src []any) error {
+	switch dst.Kind() {
+	case reflect.Slice:
+		s := reflect.MakeSlice(dst.Type(), len(src), len(src))
+		for i, e := range src {
+			if err := assignValue(s.Index(i), e); err != nil {
+				return fmt.Errorf("[%d]: %w", i, err)
+			}
+		}
+		dst.Set(s)
+		return nil
+	case reflect.Array:
+		if len(src) != dst.Len() {
+			return fmt.Errorf("cannot assign %d elements to %s", len(src), dst.Type())
+		}
+		for i, e := range src {
+			if err := assignValue(dst.Index(i), e); err != nil {
+				return fmt.Errorf("[%d]: %w", i, err)
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("cannot assign list to %s", dst.Type())
+}
+
+func assignMap(dst reflect.Value, src map[string]any) error {
+	switch dst.Kind() {
+	case reflect.Struct:
+		src = graphProperties(src)
+		for _, f := range cachedStructFields(dst.Type()) {
+			v, ok := src[f.name]
+			if !ok {
+				for k, kv := range src {
+					if strings.EqualFold(k, f.name) {
+						v, ok = kv, true
+						break
+					}
+				}
+			}
+			if !ok {
+				continue
+			}
+			fv, err := fieldByIndexAlloc(dst, f.index)
+			if err == nil {
+				err = assignValue(fv, v)
+			}
+			if err != nil {
+				return fmt.Errorf("%s: %w", f.name, err)
+			}
+		}
+		return nil
+	case reflect.Map:
+		if dst.Type().Key().Kind() != reflect.String {
+			return fmt.Errorf("cannot assign map with string keys to %s", dst.Type())
+		}
+		m := reflect.MakeMapWithSize(dst.Type(), len(src))
+		for k, v := range src {
+			ev := reflect.New(dst.Type().Elem()).Elem()
+			if err := assignValue(ev, v); err != nil {
+				return fmt.Errorf("%s: %w", k, err)
+			}
+			m.SetMapIndex(reflect.ValueOf(k).Convert(dst.Type().Key()), ev)
+		}
+		dst.Set(m)
+		return nil
+	}
+	return fmt.Errorf("cannot assign struct or map to %s", dst.Type())
+}
+
+// graphProperties returns the property map of a NODE or REL value (as produced by Row.Value),
+// or m unchanged for plain STRUCT and MAP values.
+func graphProperties(m map[string]any) map[string]any {
+	props, ok := m["properties"].(map[string]any)
+	if !ok {
+		return m
+	}
+	if _, ok := m["id"]; !ok {
+		return m
+	}
+	_, isNode := m["labels"]
+	_, isRel := m["label"]
+	if !isNode && !isRel {
+		return m
+	}
+	return props
+}
@@ -0,0 +1,198 @@
+package ladybug
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type scanAddress struct {
+	City string `lbug:"city"`
+	Zip  int32
+}
+
+type scanBase struct {
+	ID int64 `lbug:"id"`
+}
+
+type scanPerson struct {
+	scanBase
+	Name    string       `lbug:"name"`
+	Age     *int16       // NULL-able, matched case-insensitively against "age"
+	Tags    []string     `lbug:"tags"`
+	Address *scanAddress `lbug:"address"`
+	Since   time.Time    `lbug:"since"`
+	Ignored string       `lbug:"-"`
+}
+
+func TestAssignValue(t *testing.T) {
+	since := time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)
+	var p scanPerson
+	err := assignValue(reflect.ValueOf(&p).Elem(), map[string]any{
+		"id":      int64(7),
+		"name":    "ann",
+		"AGE":     int64(30),
+		"tags":    []any{"a", "b"},
+		"address": map[string]any{"city": "Oslo", "zip": int64(150)},
+		"since":   since,
+		"Ignored": "x",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.ID != 7 || p.Name != "ann" || p.Age == nil || *p.Age != 30 || len(p.Tags) != 2 ||
+		p.Address == nil || p.Address.City != "Oslo" || p.Address.Zip != 150 || !p.Since.Equal(since) || p.Ignored != "" {
+		t.Errorf("assignValue() = %+v", p)
+	}
+
+	// Node values are decoded through their properties.
+	var q scanPerson
+	node := map[string]any{"id": "0:1", "labels": []any{"Person"}, "properties": map[string]any{"name": "bob", "age": nil}}
+	if err := assignValue(reflect.ValueOf(&q).Elem(), node); err != nil {
+		t.Fatal(err)
+	}
+	if q.Name != "bob" || q.Age != nil {
+		t.Errorf("node into struct = %+v", q)
+	}
+
+	// A nil pointer to an unexported embedded struct cannot be allocated.
+	type embedded struct {
+		*scanBase
+		Name string `lbug:"name"`
+	}
+	var e embedded
+	if err := assignValue(reflect.ValueOf(&e).Elem(), map[string]any{"id": int64(7), "name": "ann"}); err == nil {
+		t.Error("nil unexported embedded pointer: want error")
+	}
+	e = embedded{scanBase: &scanBase{}}
+	if err := assignValue(reflect.ValueOf(&e).Elem(), map[string]any{"id": int64(7), "name": "ann"}); err != nil {
+		t.Fatal(err)
+	}
+	if e.ID != 7 || e.Name != "ann" {
+		t.Errorf("embedded pointer = %+v %+v", e, e.scanBase)
+	}
+
+	var small int8
+	if err := assignValue(reflect.ValueOf(&small).Elem(), int64(300)); err == nil {
+		t.Error("int8 overflow: want error")
+	}
+	var s string
+	if err := assignValue(reflect.ValueOf(&s).Elem(), int64(1)); err == nil {
+		t.Error("int into string: want error")
+	}
+}
+
+func TestScanStruct(t *testing.T) {
+	ver, _ := Version()
+	if ver == "" {
+		t.Skip("Ladybug libs not available; skipping")
+	}
+	ctx := context.Background()
+	db, err := Open(ctx, filepath.Join(t.TempDir(), "testdb"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	for _, q := range []string{
+		"CREATE NODE TABLE Person(id INT64, name STRING, age INT16, tags STRING[], PRIMARY KEY(id))",
+		"CREATE (:Person {id: 1, name: 'ann', age: 30, tags: ['a']})",
+		"CREATE (:Person {id: 2, name: 'bob', tags: []})",
+	} {
+		res, err := conn.Query(ctx, q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Close()
+	}
+
+	res, err := conn.Query(ctx, "MATCH (p:Person) RETURN p.id AS id, p.name AS name, p.age AS Age, p.tags AS tags, {city: 'Oslo', zip: 150} AS address ORDER BY id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var people []scanPerson
+	if err := res.ScanAll(&people); err != nil {
+		t.Fatal(err)
+	}
+	res.Close()
+	if len(people) != 2 || people[0].Name != "ann" || people[0].Age == nil || *people[0].Age != 30 ||
+		people[1].Age != nil || people[0].Address == nil || people[0].Address.City != "Oslo" {
+		t.Errorf("ScanAll() = %+v", people)
+	}
+
+	res, err = conn.Query(ctx, "MATCH (p:Person) WHERE p.id = 1 RETURN p")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Close()
+	row, ok := res.Next()
+	if !ok {
+		t.Fatal("expected one row")
+	}
+	var p scanPerson
+	if err := row.ScanStruct(&p); err != nil {
+		t.Fatal(err)
+	}
+	if p.ID != 1 || p.Name != "ann" || len(p.Tags) != 1 {
+		t.Errorf("ScanStruct(node) = %+v", p)
+	}
+}
+
+func TestQueryAllOne(t *testing.T) {
+	ver, _ := Version()
+	if ver == "" {
+		t.Skip("Ladybug libs not available; skipping")
+	}
+	ctx := context.Background()
+	db, err := Open(ctx, filepath.Join(t.TempDir(), "testdb"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	xs, err := QueryAll[int32](ctx, conn, "UNWIND range(1, $n) AS x RETURN x", map[string]any{"n": 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(xs) != 3 || xs[2] != 3 {
+		t.Errorf("QueryAll[int32] = %v", xs)
+	}
+
+	type pair struct {
+		A int64  `lbug:"a"`
+		B string `lbug:"b"`
+	}
+	ps, err := QueryAll[*pair](ctx, conn, "UNWIND [1, 2] AS a RETURN a, 'x' AS b", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ps) != 2 || ps[1].A != 2 || ps[1].B != "x" {
+		t.Errorf("QueryAll[*pair] = %v", ps)
+	}
+
+	p, err := QueryOne[pair](ctx, conn, "RETURN 1 AS a, 'y' AS b", nil)
+	if err != nil || p.A != 1 || p.B != "y" {
+		t.Errorf("QueryOne[pair] = %+v, %v", p, err)
+	}
+	if _, err := QueryOne[int64](ctx, conn, "UNWIND [] AS x RETURN x", nil); !errors.Is(err, ErrNoRows) {
+		t.Errorf("QueryOne on empty result: %v, want ErrNoRows", err)
+	}
+	if _, err := QueryOne[int64](ctx, conn, "UNWIND [1, 2] AS x RETURN x", nil); !errors.Is(err, ErrTooManyRows) {
+		t.Errorf("QueryOne on two rows: %v, want ErrTooManyRows", err)
+	}
+	if _, err := QueryAll[int64](ctx, conn, "RETURN 1, 2", nil); err == nil {
+		t.Error("scalar decode of two columns: want error")
+	}
+}
@@ -0,0 +1,184 @@
+package ladybug
+
+import (
+	"container/list"
+	"context"
+	"regexp"
+	"slices"
+	"sync"
+
+	"github.com/vkozio/ladybug-go-zero/internal/lbugc"
+)
+
+// StmtCacheStats reports the activity of a connection's prepared statement cache.
+type StmtCacheStats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+	// Len is the number of idle statements currently cached; Cap is the configured size.
+	Len int
+	Cap int
+}
+
+// stmtCache is a bounded LRU of idle prepared statements keyed by Cypher text.
+// A statement is removed while checked out, so concurrent callers never share a handle,
+// and put back when its PreparedStatement is closed. A statement keeps the values bound by
+// its last user, so it is only handed to callers binding exactly the same parameters;
+// otherwise a parameter the caller omitted would silently use a stale value.
+type stmtCache struct {
+	mu  sync.Mutex
+	cap int
+	ll  *list.List // of *stmtEntry; front is most recently used
+	m   map[string]*list.Element
+	// gen is bumped by clear; statements checked out under an older generation are
+	// destroyed instead of being returned.
+	gen    uint64
+	closed bool
+
+	hits, misses, evictions uint64
+}
+
+type stmtEntry struct {
+	query string
+	c     *lbugc.PreparedStatement
+	// params are the sorted names of the parameters bound in c.
+	params []string
+}
+
+func newStmtCache(size int) *stmtCache {
+	return &stmtCache{cap: size, ll: list.New(), m: make(map[string]*list.Element)}
+}
+
+// get checks out the cached statement for query, if it has exactly params (sorted) bound,
+// and returns the current generation.
+func (sc *stmtCache) get(query string, params []string) (*lbugc.PreparedStatement, uint64) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	if el, ok := sc.m[query]; ok && slices.Equal(el.Value.(*stmtEntry).params, params) {
+		sc.ll.Remove(el)
+		delete(sc.m, query)
+		sc.hits++
+		return el.Value.(*stmtEntry).c, sc.gen
+	}
+	sc.misses++
+	return nil, sc.gen
+}
+
+// put returns a checked-out statement with params (sorted) bound. It is destroyed if the
+// cache was cleared or closed since checkout, or if an idle statement for the same query is
+// already cached.
+func (sc *stmtCache) put(query string, c *lbugc.PreparedStatement, gen uint64, params []string) {
+	sc.mu.Lock()
+	if sc.closed || gen != sc.gen {
+		sc.mu.Unlock()
+		c.Close()
+		return
+	}
+	if el, ok := sc.m[query]; ok {
+		sc.ll.MoveToFront(el)
+		sc.mu.Unlock()
+		c.Close()
+		return
+	}
+	sc.m[query] = sc.ll.PushFront(&stmtEntry{query: query, c: c, params: params})
+	var evicted []*lbugc.PreparedStatement
+	for sc.ll.Len() > sc.cap {
+		e := sc.ll.Remove(sc.ll.Back()).(*stmtEntry)
+		delete(sc.m, e.query)
+		evicted = append(evicted, e.c)
+		sc.evictions++
+	}
+	sc.mu.Unlock()
+	for _, c := range evicted {
+		c.Close()
+	}
+}
+
+// clear destroys every idle statement and invalidates checked-out ones.
+func (sc *stmtCache) clear() {
+	sc.mu.Lock()
+	sc.gen++
+	idle := sc.drainLocked()
+	sc.mu.Unlock()
+	for _, c := range idle {
+		c.Close()
+	}
+}
+
+// close is clear, after which returned statements are always destroyed.
+func (sc *stmtCache) close() {
+	sc.mu.Lock()
+	sc.closed = true
+	idle := sc.drainLocked()
+	sc.mu.Unlock()
+	for _, c := range idle {
+		c.Close()
+	}
+}
+
+func (sc *stmtCache) drainLocked() []*lbugc.PreparedStatement {
+	idle := make([]*lbugc.PreparedStatement, 0, sc.ll.Len())
+	for el := sc.ll.Front(); el != nil; el = el.Next() {
+		idle = append(idle, el.Value.(*stmtEntry).c)
+	}
+	sc.ll.Init()
+	clear(sc.m)
+	return idle
+}
+
+func (sc *stmtCache) stats() StmtCacheStats {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	return StmtCacheStats{Hits: sc.hits, Misses: sc.misses, Evictions: sc.evictions, Len: sc.ll.Len(), Cap: sc.cap}
+}
+
+// schemaChangeRe matches statements after which cached plans may be stale: DDL, extension
+// loading, attaching databases, and ROLLBACK (which can undo DDL run inside the transaction).
+var schemaChangeRe = regexp.MustCompile(`(?i)\b(?:(?:CREATE|DROP|ALTER)\s+(?:NODE\s+|REL\s+)?(?:TABLE|GROUP|SEQUENCE|TYPE|MACRO|GRAPH)|INSTALL|LOAD\s+EXTENSION|ROLLBACK|IMPORT\s+DATABASE|ATTACH|DETACH)\b`)
+
+// invalidateStmtCache clears the statement cache if cypher may have changed the schema.
+func (c *Connection) invalidateStmtCache(cypher string) {
+	if c.stmts != nil && schemaChangeRe.MatchString(cypher) {
+		c.stmts.clear()
+	}
+}
+
+// prepareCached is Prepare through the connection's statement cache, if enabled, for a
+// caller that will bind the parameters in params. A cached statement is reused only if its
+// last user bound the same parameters, so every stale value gets overwritten. Closing the
+// returned statement puts it back into the cache.
+func (c *Connection) prepareCached(ctx context.Context, cypher string, params []string) (*PreparedStatement, error) {
+	if c == nil || c.stmts == nil {
+		return c.Prepare(ctx, cypher)
+	}
+	params = sortedNames(params)
+	cps, gen := c.stmts.get(cypher, params)
+	bound := make(map[string]struct{}, len(params))
+	if cps != nil {
+		for _, p := range params {
+			bound[p] = struct{}{}
+		}
+		return &PreparedStatement{c: cps, conn: c, query: cypher, cache: c.stmts, gen: gen, bound: bound}, nil
+	}
+	ps, err := c.Prepare(ctx, cypher)
+	if err != nil {
+		return nil, err
+	}
+	ps.cache, ps.gen, ps.bound = c.stmts, gen, bound
+	return ps, nil
+}
+
+// sortedNames sorts names and drops duplicates, in place.
+func sortedNames(names []string) []string {
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
+// StmtCacheStats returns the statement cache counters. It is the zero value when
+// Config.StatementCacheSize is 0.
+func (c *Connection) StmtCacheStats() StmtCacheStats {
+	if c == nil || c.stmts == nil {
+		return StmtCacheStats{}
+	}
+	return c.stmts.stats()
+}
@@ -0,0 +1,124 @@
+package ladybug
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/vkozio/ladybug-go-zero/internal/lbugc"
+)
+
+func TestStmtCacheLRU(t *testing.T) {
+	sc := newStmtCache(2)
+	for _, q := range []string{"a", "b", "c"} {
+		if c, _ := sc.get(q, nil); c != nil {
+			t.Fatalf("get(%q) hit on empty cache", q)
+		}
+		sc.put(q, &lbugc.PreparedStatement{}, 0, nil)
+	}
+	if c, _ := sc.get("a", nil); c != nil {
+		t.Error("a should have been evicted")
+	}
+	c, gen := sc.get("b", nil)
+	if c == nil {
+		t.Fatal("b should be cached")
+	}
+	if c2, _ := sc.get("b", nil); c2 != nil {
+		t.Error("a checked-out statement must not be handed out twice")
+	}
+	sc.clear()
+	sc.put("b", c, gen, nil)
+	if st := sc.stats(); st.Len != 0 {
+		t.Errorf("statement checked out before clear was cached again: %+v", st)
+	}
+	want := StmtCacheStats{Hits: 1, Misses: 5, Evictions: 1, Cap: 2}
+	if st := sc.stats(); st != want {
+		t.Errorf("stats = %+v, want %+v", st, want)
+	}
+}
+
+func TestStmtCacheParams(t *testing.T) {
+	sc := newStmtCache(2)
+	sc.put("q", &lbugc.PreparedStatement{}, 0, []string{"x", "y"})
+	if c, _ := sc.get("q", []string{"x"}); c != nil {
+		t.Error("statement with x and y bound handed to a caller binding only x")
+	}
+	if c, _ := sc.get("q", []string{"x", "y", "z"}); c != nil {
+		t.Error("statement with x and y bound handed to a caller binding x, y and z")
+	}
+	if c, _ := sc.get("q", sortedNames([]string{"y", "x", "y"})); c == nil {
+		t.Error("statement not reused for the same parameters")
+	}
+}
+
+func TestSchemaChangeRe(t *testing.T) {
+	for q, want := range map[string]bool{
+		"CREATE NODE TABLE Person(name STRING, PRIMARY KEY(name))": true,
+		"create rel table Knows(FROM Person TO Person)":            true,
+		"ALTER TABLE Person ADD age INT64":                         true,
+		"DROP TABLE Person":                                        true,
+		"ROLLBACK":                                                 true,
+		"CREATE (:Person {name: 'a'})":                             false,
+		"MATCH (p:Person) RETURN p.name":                           false,
+	} {
+		if got := schemaChangeRe.MatchString(q); got != want {
+			t.Errorf("schemaChangeRe(%q) = %v, want %v", q, got, want)
+		}
+	}
+}
+
+func TestStmtCacheConnection(t *testing.T) {
+	ver, _ := Version()
+	if ver == "" {
+		t.Skip("Ladybug libs not available; skipping")
+	}
+	ctx := context.Background()
+	db, err := Open(ctx, filepath.Join(t.TempDir(), "testdb"), &Config{StatementCacheSize: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	run := func(q string, params map[string]any) {
+		t.Helper()
+		res, err := conn.QueryParams(ctx, q, params)
+		if err != nil {
+			t.Fatalf("%s: %v", q, err)
+		}
+		res.Close()
+	}
+	for i := 0; i < 3; i++ {
+		run("RETURN $x + 1", map[string]any{"x": i})
+	}
+	if st := conn.StmtCacheStats(); st.Hits != 2 || st.Misses != 1 || st.Len != 1 {
+		t.Errorf("after repeats: %+v", st)
+	}
+	if _, err := conn.Query(ctx, "CREATE NODE TABLE T(id INT64, PRIMARY KEY(id))"); err != nil {
+		t.Fatal(err)
+	}
+	if st := conn.StmtCacheStats(); st.Len != 0 {
+		t.Errorf("DDL did not clear the cache: %+v", st)
+	}
+	run("RETURN $x + 1", map[string]any{"x": 1})
+	if st := conn.StmtCacheStats(); st.Misses != 2 {
+		t.Errorf("after DDL: %+v", st)
+	}
+
+	// A call omitting a parameter must not run with the previous caller's value.
+	run("RETURN $x + $y", map[string]any{"x": 1, "y": 2})
+	if res, err := conn.QueryParams(ctx, "RETURN $x + $y", map[string]any{"x": 1}); err == nil {
+		row, _ := res.Next()
+		v, _ := row.Value(0)
+		res.Close()
+		t.Errorf("query with $y unbound succeeded: %v", v)
+	}
+	if res, err := conn.QueryNamed(ctx, "RETURN $x + $y", Named("x", 1)); err == nil {
+		res.Close()
+		t.Error("QueryNamed with $y unbound succeeded")
+	}
+}
@@ -0,0 +1,176 @@
+package ladybug
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/vkozio/ladybug-go-zero/internal/lbugc"
+)
+
+// Date is a DATE value: a calendar day with no time of day or time zone. Row.Value returns
+// DATE columns as Date, so the day does not shift with the caller's time zone.
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// DateOf returns the calendar day of t in t's location.
+func DateOf(t time.Time) Date {
+	y, m, d := t.Date()
+	return Date{Year: y, Month: m, Day: d}
+}
+
+// dateFromDays returns the Date that is days after 1970-01-01.
+func dateFromDays(days int64) Date {
+	return DateOf(time.Unix(days*86400, 0).UTC())
+}
+
+// ParseDate parses a date in the form "2006-01-02".
+func ParseDate(s string) (Date, error) {
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return Date{}, fmt.Errorf("ladybug: invalid date %q", s)
+	}
+	return DateOf(t), nil
+}
+
+// In returns midnight at the start of d in loc.
+func (d Date) In(loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, loc)
+}
+
+// IsZero reports whether d is the zero Date.
+func (d Date) IsZero() bool {
+	return d == Date{}
+}
+
+// String returns d in the form "2006-01-02".
+func (d Date) String() string {
+	return d.In(time.UTC).Format(time.DateOnly)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (d Date) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (d *Date) UnmarshalText(b []byte) error {
+	v, err := ParseDate(string(b))
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// Scan implements sql.Scanner for Date, time.Time (its day in its location) and
+// "2006-01-02" string sources.
+func (d *Date) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*d = Date{}
+	case Date:
+		*d = v
+	case time.Time:
+		*d = DateOf(v)
+	case string:
+		return d.UnmarshalText([]byte(v))
+	case []byte:
+		return d.UnmarshalText(v)
+	default:
+		return fmt.Errorf("ladybug: cannot scan %T into Date", src)
+	}
+	return nil
+}
+
+// TimestampPrecision is the storage type of a timestamp column.
+type TimestampPrecision uint8
+
+// Timestamp precisions, one per Ladybug timestamp type.
+const (
+	TimestampMicros TimestampPrecision = iota // TIMESTAMP
+	TimestampSec                              // TIMESTAMP_SEC
+	TimestampMillis                           // TIMESTAMP_MS
+	TimestampNanos                            // TIMESTAMP_NS
+	TimestampTZ                               // TIMESTAMP_TZ, microseconds
+)
+
+// String returns the Ladybug type name of p, such as "TIMESTAMP_MS".
+func (p TimestampPrecision) String() string {
+	return p.typeID().String()
+}
+
+func (p TimestampPrecision) typeID() TypeID {
+	switch p {
+	case TimestampSec:
+		return TypeTimestampSec
+	case TimestampMillis:
+		return TypeTimestampMS
+	case TimestampNanos:
+		return TypeTimestampNS
+	case TimestampTZ:
+		return TypeTimestampTZ
+	}
+	return TypeTimestamp
+}
+
+// Timestamp is a timestamp together with the type it was stored as. Scanning a timestamp
+// column into a Timestamp keeps its precision, and binding it creates a value of the same
+// type, so values round-trip exactly. Time is truncated to Precision when bound.
+type Timestamp struct {
+	Time      time.Time
+	Precision TimestampPrecision
+}
+
+// timestampFromDriver converts an lbugc.Timestamp, rendering it in loc.
+func timestampFromDriver(ts lbugc.Timestamp, loc *time.Location) Timestamp {
+	// TimestampPrecision and lbugc.TimestampUnit share their order.
+	return Timestamp{Time: ts.Time().In(loc), Precision: TimestampPrecision(ts.Unit)}
+}
+
+// driverValue returns ts in units of its precision since the Unix epoch.
+func (ts Timestamp) driverValue() lbugc.Timestamp {
+	var v int64
+	switch ts.Precision {
+	case TimestampSec:
+		v = ts.Time.Unix()
+	case TimestampMillis:
+		v = ts.Time.UnixMilli()
+	case TimestampNanos:
+		v = ts.Time.UnixNano()
+	default:
+		v = ts.Time.UnixMicro()
+	}
+	return lbugc.Timestamp{Value: v, Unit: lbugc.TimestampUnit(ts.Precision)}
+}
+
+// String returns Time in RFC 3339 format with the precision's fractional digits.
+func (ts Timestamp) String() string {
+	switch ts.Precision {
+	case TimestampSec:
+		return ts.Time.Format(time.RFC3339)
+	case TimestampMillis:
+		return ts.Time.Format("2006-01-02T15:04:05.000Z07:00")
+	case TimestampNanos:
+		return ts.Time.Format("2006-01-02T15:04:05.000000000Z07:00")
+	}
+	return ts.Time.Format("2006-01-02T15:04:05.000000Z07:00")
+}
+
+// Scan implements sql.Scanner for Timestamp and time.Time sources. A time.Time carries no
+// precision and scans as TimestampNanos.
+func (ts *Timestamp) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*ts = Timestamp{}
+	case Timestamp:
+		*ts = v
+	case time.Time:
+		*ts = Timestamp{Time: v, Precision: TimestampNanos}
+	default:
+		return fmt.Errorf("ladybug: cannot scan %T into Timestamp", src)
+	}
+	return nil
+}
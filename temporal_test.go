@@ -0,0 +1,232 @@
+package ladybug
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/vkozio/ladybug-go-zero/internal/lbugc"
+)
+
+func TestDate(t *testing.T) {
+	d, err := ParseDate("2024-02-29")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d != (Date{2024, time.February, 29}) || d.String() != "2024-02-29" {
+		t.Errorf("ParseDate = %+v (%s)", d, d)
+	}
+	for _, bad := range []string{"", "2023-02-29", "2024-2-1", "2024-02-01T00:00:00Z"} {
+		if _, err := ParseDate(bad); err == nil {
+			t.Errorf("ParseDate(%q) succeeded", bad)
+		}
+	}
+	if got := dateFromDays(-1); got != (Date{1969, time.December, 31}) {
+		t.Errorf("dateFromDays(-1) = %s", got)
+	}
+	if got := dateFromDays(19782); got != (Date{2024, time.February, 29}) {
+		t.Errorf("dateFromDays(19782) = %s", got)
+	}
+
+	// The day of a time.Time is taken in its own location, not UTC.
+	tokyo := time.FixedZone("JST", 9*3600)
+	if got := DateOf(time.Date(2024, 3, 1, 1, 0, 0, 0, tokyo)); got != (Date{2024, time.March, 1}) {
+		t.Errorf("DateOf = %s", got)
+	}
+	if got := d.In(tokyo); !got.Equal(time.Date(2024, 2, 29, 0, 0, 0, 0, tokyo)) {
+		t.Errorf("In = %v", got)
+	}
+
+	var dst struct {
+		D Date
+		T time.Time
+		P *Date
+		S Date
+	}
+	for name, v := range map[string]any{"D": d, "T": d, "P": d, "S": "2024-02-29"} {
+		f, _ := reflect.TypeOf(dst).FieldByName(name)
+		if err := assignValue(reflect.ValueOf(&dst).Elem().FieldByIndex(f.Index), v); err != nil {
+			t.Errorf("assign %s: %v", name, err)
+		}
+	}
+	if dst.D != d || !dst.T.Equal(d.In(time.UTC)) || dst.P == nil || *dst.P != d || dst.S != d {
+		t.Errorf("decoded = %+v", dst)
+	}
+}
+
+func TestTimestamp(t *testing.T) {
+	at := time.Date(2024, 5, 6, 7, 8, 9, 123456789, time.UTC)
+	for _, tc := range []struct {
+		prec  TimestampPrecision
+		value int64
+		name  string
+		out   string
+	}{
+		{TimestampSec, at.Unix(), "TIMESTAMP_SEC", "2024-05-06T07:08:09Z"},
+		{TimestampMillis, at.UnixMilli(), "TIMESTAMP_MS", "2024-05-06T07:08:09.123Z"},
+		{TimestampMicros, at.UnixMicro(), "TIMESTAMP", "2024-05-06T07:08:09.123456Z"},
+		{TimestampNanos, at.UnixNano(), "TIMESTAMP_NS", "2024-05-06T07:08:09.123456789Z"},
+		{TimestampTZ, at.UnixMicro(), "TIMESTAMP_TZ", "2024-05-06T07:08:09.123456Z"},
+	} {
+		ts := Timestamp{Time: at, Precision: tc.prec}
+		dv := ts.driverValue()
+		if dv.Value != tc.value || dv.Unit != lbugc.TimestampUnit(tc.prec) {
+			t.Errorf("%s: driverValue = %+v, want %d", tc.name, dv, tc.value)
+		}
+		back := timestampFromDriver(dv, time.UTC)
+		if back.Precision != tc.prec || back.driverValue() != dv {
+			t.Errorf("%s: round trip = %+v", tc.name, back)
+		}
+		if s := back.String(); s != tc.out {
+			t.Errorf("%s: String = %q, want %q", tc.name, s, tc.out)
+		}
+		if s := tc.prec.String(); s != tc.name {
+			t.Errorf("precision String = %q, want %q", s, tc.name)
+		}
+	}
+
+	// Timestamps render in the requested location; typed values keep their precision.
+	berlin := time.FixedZone("CET", 3600)
+	raw := lbugc.Timestamp{Value: at.UnixMilli(), Unit: lbugc.TimestampMillis}
+	v := fromDriver([]any{raw, lbugc.Date(19782)}, berlin, false).([]any)
+	if tm, ok := v[0].(time.Time); !ok || tm.Location() != berlin || !tm.Equal(at.Truncate(time.Millisecond)) {
+		t.Errorf("time value = %#v", v[0])
+	}
+	if d, ok := v[1].(Date); !ok || d != (Date{2024, time.February, 29}) {
+		t.Errorf("date value = %#v", v[1])
+	}
+	ts, ok := fromDriver(raw, berlin, true).(Timestamp)
+	if !ok || ts.Precision != TimestampMillis || ts.Time.Location() != berlin {
+		t.Errorf("typed value = %#v", ts)
+	}
+
+	var dst struct {
+		T Timestamp
+		P *Timestamp
+		N Timestamp
+	}
+	for name, v := range map[string]any{"T": ts, "P": ts, "N": at} {
+		f, _ := reflect.TypeOf(dst).FieldByName(name)
+		if err := assignValue(reflect.ValueOf(&dst).Elem().FieldByIndex(f.Index), v); err != nil {
+			t.Errorf("assign %s: %v", name, err)
+		}
+	}
+	if dst.T != ts || dst.P == nil || *dst.P != ts || dst.N.Precision != TimestampNanos || !dst.N.Time.Equal(at) {
+		t.Errorf("decoded = %+v", dst)
+	}
+	if !isTimestampType(reflect.TypeOf(&ts)) || isTimestampType(timeType) {
+		t.Error("isTimestampType is wrong")
+	}
+	for unit, want := range map[*arrow.TimestampType]TimestampPrecision{
+		{Unit: arrow.Second}:                       TimestampSec,
+		{Unit: arrow.Millisecond}:                  TimestampMillis,
+		{Unit: arrow.Microsecond}:                  TimestampMicros,
+		{Unit: arrow.Microsecond, TimeZone: "UTC"}: TimestampTZ,
+		{Unit: arrow.Nanosecond}:                   TimestampNanos,
+	} {
+		if got := arrowPrecision(unit); got != want {
+			t.Errorf("arrowPrecision(%s) = %s, want %s", unit, got, want)
+		}
+	}
+}
+
+func TestTemporalRoundTrip(t *testing.T) {
+	ver, _ := Version()
+	if ver == "" {
+		t.Skip("Ladybug libs not available; skipping")
+	}
+	ctx := context.Background()
+	tokyo := time.FixedZone("JST", 9*3600)
+	db, err := Open(ctx, filepath.Join(t.TempDir(), "testdb"), &Config{Location: tokyo})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	day := Date{2024, time.February, 29}
+	got, err := QueryOne[Date](ctx, conn, "RETURN $d", map[string]any{"d": day})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != day {
+		t.Errorf("DATE round trip = %s", got)
+	}
+
+	at := time.Date(2024, 5, 6, 7, 8, 9, 123456789, time.UTC)
+	for _, prec := range []TimestampPrecision{TimestampSec, TimestampMillis, TimestampMicros, TimestampNanos, TimestampTZ} {
+		in := Timestamp{Time: at, Precision: prec}
+		res, err := conn.QueryParams(ctx, "RETURN $t", map[string]any{"t": in})
+		if err != nil {
+			t.Fatal(err)
+		}
+		cols, _ := res.Columns()
+		row, ok := res.Next()
+		if !ok {
+			t.Fatal("expected one row")
+		}
+		var out Timestamp
+		if err := row.Scan(&out); err != nil {
+			t.Fatal(err)
+		}
+		res.Close()
+		if cols[0].Type.ID != prec.typeID() || out.Precision != prec || out.driverValue() != in.driverValue() {
+			t.Errorf("%s round trip = %+v (column %s)", prec, out, cols[0].Type.ID)
+		}
+		if out.Time.Location() != tokyo {
+			t.Errorf("%s rendered in %s, want JST", prec, out.Time.Location())
+		}
+	}
+
+	res, err := conn.QueryParams(ctx, "RETURN $t", map[string]any{"t": at})
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := DecodeAll[time.Time](res, 0)
+	res.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded) != 1 || decoded[0].Location() != tokyo || !decoded[0].Equal(at.Truncate(time.Microsecond)) {
+		t.Errorf("DecodeAll = %v, want %v in JST", decoded, at)
+	}
+
+	tm, err := QueryOne[time.Time](ctx, conn, "RETURN $t", map[string]any{"t": at}, WithLocation(time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tm.Location() != time.UTC || !tm.Equal(at) {
+		t.Errorf("WithLocation(UTC) = %v", tm)
+	}
+
+	// Times UnixNano cannot hold bind as microsecond TIMESTAMP.
+	for _, far := range []time.Time{
+		time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(1600, 3, 4, 5, 6, 7, 8000, time.UTC),
+		time.Date(9999, 12, 31, 23, 59, 59, 999999000, time.UTC),
+	} {
+		res, err := conn.QueryParams(ctx, "RETURN $t", map[string]any{"t": far})
+		if err != nil {
+			t.Fatal(err)
+		}
+		cols, _ := res.Columns()
+		res.Close()
+		if cols[0].Type.ID != TimestampMicros.typeID() {
+			t.Errorf("%v bound as %s, want TIMESTAMP", far, cols[0].Type.ID)
+		}
+		tm, err := QueryOne[time.Time](ctx, conn, "RETURN $t", map[string]any{"t": far})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !tm.Equal(far) {
+			t.Errorf("round trip of %v = %v", far, tm)
+		}
+	}
+}
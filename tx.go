@@ -0,0 +1,212 @@
+package ladybug
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// TxOptions configures BeginTx and RunInTx.
+type TxOptions struct {
+	// ReadOnly starts the transaction with BEGIN TRANSACTION READ ONLY.
+	ReadOnly bool
+	// MaxRetries is how many times RunInTx re-runs fn after a transaction conflict (0 = never).
+	MaxRetries int
+}
+
+// Tx is an explicit transaction on a Connection, started by BeginTx. It must end with Commit
+// or Rollback; it is rolled back automatically when the context passed to BeginTx is done.
+// While a Tx is open, statements sent directly on its Connection also run inside it.
+type Tx struct {
+	conn *Connection
+	ctx  context.Context
+	stop func() bool
+
+	readOnly bool
+
+	mu sync.Mutex
+	// done is set under mu; it is atomic so that Connection can check it while a Tx method
+	// holds mu.
+	done atomic.Bool
+}
+
+// BeginTx starts a transaction. ctx bounds the whole transaction: when it is canceled or its
+// deadline passes, the running statement is interrupted and the transaction rolled back.
+func (c *Connection) BeginTx(ctx context.Context, opts TxOptions) (*Tx, error) {
+	if c == nil || c.c == nil {
+		return nil, ErrInvalidConn
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	stmt := "BEGIN TRANSACTION"
+	if opts.ReadOnly {
+		stmt += " READ ONLY"
+	}
+	res, err := c.Query(ctx, stmt)
+	if err != nil {
+		return nil, err
+	}
+	res.Close()
+	tx := &Tx{conn: c, ctx: ctx, readOnly: opts.ReadOnly}
+	c.tx.Store(tx)
+	tx.stop = context.AfterFunc(ctx, tx.abort)
+	return tx, nil
+}
+
+// abort interrupts the running statement and rolls back; it runs when tx.ctx is done.
+func (tx *Tx) abort() {
+	tx.conn.Interrupt()
+	_ = tx.finish("ROLLBACK")
+}
+
+// Query runs cypher inside the transaction. Caller must call Result.Close.
+func (tx *Tx) Query(ctx context.Context, cypher string, opts ...QueryOption) (*Result, error) {
+	if err := tx.lock(); err != nil {
+		return nil, err
+	}
+	defer tx.mu.Unlock()
+	return tx.conn.Query(ctx, cypher, opts...)
+}
+
+// QueryParams is Connection.QueryParams inside the transaction.
+func (tx *Tx) QueryParams(ctx context.Context, cypher string, params map[string]any, opts ...QueryOption) (*Result, error) {
+	if err := tx.lock(); err != nil {
+		return nil, err
+	}
+	defer tx.mu.Unlock()
+	return tx.conn.QueryParams(ctx, cypher, params, opts...)
+}
+
+// QueryNamed is Connection.QueryNamed inside the transaction.
+func (tx *Tx) QueryNamed(ctx context.Context, cypher string, args ...NamedArg) (*Result, error) {
+	if err := tx.lock(); err != nil {
+		return nil, err
+	}
+	defer tx.mu.Unlock()
+	return tx.conn.QueryNamed(ctx, cypher, args...)
+}
+
+// Prepare prepares cypher on the transaction's connection. The statement may be executed
+// until the transaction ends. Caller must call PreparedStatement.Close.
+func (tx *Tx) Prepare(ctx context.Context, cypher string) (*PreparedStatement, error) {
+	if err := tx.lock(); err != nil {
+		return nil, err
+	}
+	defer tx.mu.Unlock()
+	return tx.conn.Prepare(ctx, cypher)
+}
+
+// Commit commits the transaction. It returns ErrTxDone if the transaction has already
+// ended, or the context error if it was rolled back because its context is done.
+func (tx *Tx) Commit() error {
+	if tx.stop != nil {
+		tx.stop()
+	}
+	if err := tx.ctx.Err(); err != nil {
+		_ = tx.finish("ROLLBACK")
+		return err
+	}
+	if err := tx.finish("COMMIT"); err != nil {
+		if errors.Is(err, ErrTxDone) && tx.ctx.Err() != nil {
+			return tx.ctx.Err()
+		}
+		return err
+	}
+	return nil
+}
+
+// Rollback aborts the transaction. It returns ErrTxDone if the transaction has already ended.
+func (tx *Tx) Rollback() error {
+	if tx.stop != nil {
+		tx.stop()
+	}
+	return tx.finish("ROLLBACK")
+}
+
+// lock acquires tx.mu if the transaction is still open.
+func (tx *Tx) lock() error {
+	tx.mu.Lock()
+	if tx.done.Load() {
+		tx.mu.Unlock()
+		if err := tx.ctx.Err(); err != nil {
+			return err
+		}
+		return ErrTxDone
+	}
+	return nil
+}
+
+func (tx *Tx) finish(stmt string) error {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+	if tx.done.Load() {
+		return ErrTxDone
+	}
+	tx.done.Store(true)
+	tx.conn.tx.CompareAndSwap(tx, nil)
+	res, err := tx.conn.Query(context.Background(), stmt)
+	if err != nil {
+		return err
+	}
+	res.Close()
+	return nil
+}
+
+// RunInTx runs fn in a transaction and commits it if fn returns nil. If fn returns an error
+// or panics, the transaction is rolled back and the error (or panic) is passed on. When the
+// transaction fails with a conflict, fn is run again in a new transaction up to
+// opts.MaxRetries times, with a short growing delay in between.
+func (c *Connection) RunInTx(ctx context.Context, opts TxOptions, fn func(*Tx) error) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	delay := 5 * time.Millisecond
+	for attempt := 0; ; attempt++ {
+		err := c.runTx(ctx, opts, fn)
+		if err == nil || attempt >= opts.MaxRetries || !isTxConflict(err) {
+			return err
+		}
+		t := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return err
+		case <-t.C:
+		}
+		delay = min(2*delay, time.Second)
+	}
+}
+
+func (c *Connection) runTx(ctx context.Context, opts TxOptions, fn func(*Tx) error) (err error) {
+	tx, err := c.BeginTx(ctx, opts)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+	if err := fn(tx); err != nil {
+		if rerr := tx.Rollback(); rerr != nil && !errors.Is(rerr, ErrTxDone) {
+			return fmt.Errorf("%w (rollback: %v)", err, rerr)
+		}
+		return err
+	}
+	return tx.Commit()
+}
+
+// isTxConflict reports whether err is a transaction error caused by a concurrent writer.
+func isTxConflict(err error) bool {
+	var e *Error
+	if !errors.As(err, &e) || e.Kind != KindTransaction {
+		return false
+	}
+	return strings.Contains(strings.ToLower(e.Message), "conflict")
+}
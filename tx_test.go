@@ -0,0 +1,188 @@
+package ladybug
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestIsTxConflict(t *testing.T) {
+	if !isTxConflict(&Error{Kind: KindTransaction, Message: "Runtime exception: Write-write conflict of updating the same row."}) {
+		t.Error("write-write conflict not detected")
+	}
+	if isTxConflict(&Error{Kind: KindTransaction, Message: "Transaction exception: no active transaction"}) {
+		t.Error("non-conflict transaction error detected as conflict")
+	}
+	if isTxConflict(errors.New("conflict")) {
+		t.Error("plain error detected as conflict")
+	}
+}
+
+func TestTx(t *testing.T) {
+	ver, _ := Version()
+	if ver == "" {
+		t.Skip("Ladybug libs not available; skipping")
+	}
+	ctx := context.Background()
+	db, err := Open(ctx, filepath.Join(t.TempDir(), "testdb"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	exec := func(tx *Tx, q string) {
+		t.Helper()
+		res, err := tx.Query(ctx, q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Close()
+	}
+	count := func() int64 {
+		t.Helper()
+		n, err := QueryOne[int64](ctx, conn, "MATCH (p:Person) RETURN count(p)", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return n
+	}
+	res, err := conn.Query(ctx, "CREATE NODE TABLE Person(name STRING PRIMARY KEY)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Close()
+
+	tx, err := conn.BeginTx(ctx, TxOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	exec(tx, "CREATE (:Person {name: 'ann'})")
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Rollback(); !errors.Is(err, ErrTxDone) {
+		t.Errorf("Rollback after Commit = %v, want ErrTxDone", err)
+	}
+	if conn.tx.Load() != nil {
+		t.Error("connection still records the committed transaction")
+	}
+	if _, err := tx.Query(ctx, "RETURN 1"); !errors.Is(err, ErrTxDone) {
+		t.Errorf("Query after Commit = %v, want ErrTxDone", err)
+	}
+
+	tx, err = conn.BeginTx(ctx, TxOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	exec(tx, "CREATE (:Person {name: 'bob'})")
+	if err := tx.Rollback(); err != nil {
+		t.Fatal(err)
+	}
+	if n := count(); n != 1 {
+		t.Errorf("count after rollback = %d, want 1", n)
+	}
+
+	tx, err = conn.BeginTx(ctx, TxOptions{ReadOnly: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tx.Query(ctx, "CREATE (:Person {name: 'cat'})"); err == nil {
+		t.Error("write in read-only transaction succeeded")
+	}
+	_ = tx.Rollback()
+
+	cctx, cancel := context.WithCancel(ctx)
+	tx, err = conn.BeginTx(cctx, TxOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	exec(tx, "CREATE (:Person {name: 'dan'})")
+	cancel()
+	if err := tx.Commit(); !errors.Is(err, context.Canceled) {
+		t.Errorf("Commit after cancel = %v, want context.Canceled", err)
+	}
+	if n := count(); n != 1 {
+		t.Errorf("count after cancel = %d, want 1", n)
+	}
+
+	errFail := errors.New("fail")
+	err = conn.RunInTx(ctx, TxOptions{}, func(tx *Tx) error {
+		exec(tx, "CREATE (:Person {name: 'eve'})")
+		return errFail
+	})
+	if !errors.Is(err, errFail) {
+		t.Errorf("RunInTx = %v, want %v", err, errFail)
+	}
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("RunInTx swallowed panic")
+			}
+		}()
+		_ = conn.RunInTx(ctx, TxOptions{}, func(tx *Tx) error {
+			exec(tx, "CREATE (:Person {name: 'fay'})")
+			panic("boom")
+		})
+	}()
+	if err := conn.RunInTx(ctx, TxOptions{MaxRetries: 3}, func(tx *Tx) error {
+		res, err := tx.QueryParams(ctx, "CREATE (:Person {name: $name})", map[string]any{"name": "gus"})
+		if err != nil {
+			return err
+		}
+		return res.Close()
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if n := count(); n != 2 {
+		t.Errorf("final count = %d, want 2", n)
+	}
+}
+
+// TestTxConcurrent runs BeginTx alongside calls that read the connection's transaction; run
+// it with -race.
+func TestTxConcurrent(t *testing.T) {
+	ver, _ := Version()
+	if ver == "" {
+		t.Skip("Ladybug libs not available; skipping")
+	}
+	ctx := context.Background()
+	db, err := Open(ctx, filepath.Join(t.TempDir(), "testdb"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for range 20 {
+			// Errors are expected while the other goroutine's transaction is open.
+			if res, err := conn.Query(ctx, "RETURN 1", WithReadOnly()); err == nil {
+				res.Close()
+			}
+		}
+	}()
+	for range 20 {
+		tx, err := conn.BeginTx(ctx, TxOptions{ReadOnly: true})
+		if err != nil {
+			continue
+		}
+		_ = tx.Rollback()
+	}
+	wg.Wait()
+	if conn.tx.Load() != nil {
+		t.Error("connection still records a finished transaction")
+	}
+}
@@ -0,0 +1,259 @@
+package ladybug
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/apache/arrow-go/v18/arrow"
+)
+
+// TypeID identifies a Ladybug logical type. Values match lbug_data_type_id in lbug.h.
+type TypeID int
+
+const (
+	TypeAny          TypeID = 0
+	TypeNode         TypeID = 10
+	TypeRel          TypeID = 11
+	TypeRecursiveRel TypeID = 12
+	TypeSerial       TypeID = 13
+	TypeBool         TypeID = 22
+	TypeInt64        TypeID = 23
+	TypeInt32        TypeID = 24
+	TypeInt16        TypeID = 25
+	TypeInt8         TypeID = 26
+	TypeUInt64       TypeID = 27
+	TypeUInt32       TypeID = 28
+	TypeUInt16       TypeID = 29
+	TypeUInt8        TypeID = 30
+	TypeInt128       TypeID = 31
+	TypeDouble       TypeID = 32
+	TypeFloat        TypeID = 33
+	TypeDate         TypeID = 34
+	TypeTimestamp    TypeID = 35
+	TypeTimestampSec TypeID = 36
+	TypeTimestampMS  TypeID = 37
+	TypeTimestampNS  TypeID = 38
+	TypeTimestampTZ  TypeID = 39
+	TypeInterval     TypeID = 40
+	TypeDecimal      TypeID = 41
+	TypeInternalID   TypeID = 42
+	TypeString       TypeID = 50
+	TypeBlob         TypeID = 51
+	TypeList         TypeID = 52
+	TypeArray        TypeID = 53
+	TypeStruct       TypeID = 54
+	TypeMap          TypeID = 55
+	TypeUnion        TypeID = 56
+	TypePointer      TypeID = 58
+	TypeUUID         TypeID = 59
+)
+
+var typeIDNames = map[TypeID]string{
+	TypeAny:          "ANY",
+	TypeNode:         "NODE",
+	TypeRel:          "REL",
+	TypeRecursiveRel: "RECURSIVE_REL",
+	TypeSerial:       "SERIAL",
+	TypeBool:         "BOOL",
+	TypeInt64:        "INT64",
+	TypeInt32:        "INT32",
+	TypeInt16:        "INT16",
+	TypeInt8:         "INT8",
+	TypeUInt64:       "UINT64",
+	TypeUInt32:       "UINT32",
+	TypeUInt16:       "UINT16",
+	TypeUInt8:        "UINT8",
+	TypeInt128:       "INT128",
+	TypeDouble:       "DOUBLE",
+	TypeFloat:        "FLOAT",
+	TypeDate:         "DATE",
+	TypeTimestamp:    "TIMESTAMP",
+	TypeTimestampSec: "TIMESTAMP_SEC",
+	TypeTimestampMS:  "TIMESTAMP_MS",
+	TypeTimestampNS:  "TIMESTAMP_NS",
+	TypeTimestampTZ:  "TIMESTAMP_TZ",
+	TypeInterval:     "INTERVAL",
+	TypeDecimal:      "DECIMAL",
+	TypeInternalID:   "INTERNAL_ID",
+	TypeString:       "STRING",
+	TypeBlob:         "BLOB",
+	TypeList:         "LIST",
+	TypeArray:        "ARRAY",
+	TypeStruct:       "STRUCT",
+	TypeMap:          "MAP",
+	TypeUnion:        "UNION",
+	TypePointer:      "POINTER",
+	TypeUUID:         "UUID",
+}
+
+// String returns the Ladybug type name, e.g. "INT64" or "NODE".
+func (id TypeID) String() string {
+	if s, ok := typeIDNames[id]; ok {
+		return s
+	}
+	return fmt.Sprintf("TypeID(%d)", int(id))
+}
+
+// LogicalType describes the type of a column or nested value.
+type LogicalType struct {
+	ID TypeID
+	// Elem is the element type of LIST and ARRAY types.
+	Elem *LogicalType
+	// ArrayLen is the fixed number of elements of an ARRAY type.
+	ArrayLen uint64
+	// Fields are the members of STRUCT, NODE, REL, RECURSIVE_REL and UNION types.
+	// MAP types have exactly two fields, "key" and "value".
+	Fields []Field
+}
+
+// Field is a named member of a nested LogicalType.
+type Field struct {
+	Name string
+	Type LogicalType
+}
+
+// String formats t in Cypher DDL syntax, e.g. "INT64[]", "DOUBLE[3]" or "STRUCT(a INT64, b STRING)".
+func (t LogicalType) String() string {
+	switch t.ID {
+	case TypeList:
+		if t.Elem != nil {
+			return t.Elem.String() + "[]"
+		}
+	case TypeArray:
+		if t.Elem != nil {
+			return fmt.Sprintf("%s[%d]", t.Elem.String(), t.ArrayLen)
+		}
+	case TypeMap:
+		if len(t.Fields) == 2 {
+			return fmt.Sprintf("MAP(%s, %s)", t.Fields[0].Type, t.Fields[1].Type)
+		}
+	case TypeStruct, TypeUnion:
+		if len(t.Fields) > 0 {
+			parts := make([]string, len(t.Fields))
+			for i, f := range t.Fields {
+				parts[i] = f.Name + " " + f.Type.String()
+			}
+			return fmt.Sprintf("%s(%s)", t.ID, strings.Join(parts, ", "))
+		}
+	}
+	return t.ID.String()
+}
+
+// Column describes one column of a Result.
+type Column struct {
+	Name string
+	Type LogicalType
+}
+
+// Columns returns the name and type of every column of the current result set. It does not
+// consume rows, so it is valid for empty results. The top-level type comes from the C API;
+// nested element and field types are derived from the result's Arrow schema.
+func (r *Result) Columns() ([]Column, error) {
+	if r == nil || r.c == nil {
+		return nil, ErrClosed
+	}
+	if r.columns != nil {
+		return r.columns, nil
+	}
+	n := r.c.NumColumns()
+	sc := r.Schema()
+	cols := make([]Column, n)
+	for i := uint64(0); i < n; i++ {
+		id, arrayLen, err := r.c.ColumnType(i)
+		if err != nil {
+			return nil, wrapErr(err)
+		}
+		t := LogicalType{ID: TypeID(id), ArrayLen: arrayLen}
+		if sc != nil && int(i) < sc.NumFields() {
+			nested := logicalTypeFromArrow(sc.Field(int(i)).Type)
+			t.Elem = nested.Elem
+			t.Fields = nested.Fields
+			if t.ArrayLen == 0 {
+				t.ArrayLen = nested.ArrayLen
+			}
+		}
+		cols[i] = Column{Name: r.c.ColumnName(i), Type: t}
+	}
+	r.columns = cols
+	return cols, nil
+}
+
+// logicalTypeFromArrow maps an Arrow type produced by Ladybug's Arrow export back to a LogicalType.
+func logicalTypeFromArrow(dt arrow.DataType) LogicalType {
+	switch t := dt.(type) {
+	case *arrow.BooleanType:
+		return LogicalType{ID: TypeBool}
+	case *arrow.Int8Type:
+		return LogicalType{ID: TypeInt8}
+	case *arrow.Int16Type:
+		return LogicalType{ID: TypeInt16}
+	case *arrow.Int32Type:
+		return LogicalType{ID: TypeInt32}
+	case *arrow.Int64Type:
+		return LogicalType{ID: TypeInt64}
+	case *arrow.Uint8Type:
+		return LogicalType{ID: TypeUInt8}
+	case *arrow.Uint16Type:
+		return LogicalType{ID: TypeUInt16}
+	case *arrow.Uint32Type:
+		return LogicalType{ID: TypeUInt32}
+	case *arrow.Uint64Type:
+		return LogicalType{ID: TypeUInt64}
+	case *arrow.Float32Type:
+		return LogicalType{ID: TypeFloat}
+	case *arrow.Float64Type:
+		return LogicalType{ID: TypeDouble}
+	case *arrow.StringType, *arrow.LargeStringType:
+		return LogicalType{ID: TypeString}
+	case *arrow.BinaryType, *arrow.LargeBinaryType:
+		return LogicalType{ID: TypeBlob}
+	case *arrow.Date32Type, *arrow.Date64Type:
+		return LogicalType{ID: TypeDate}
+	case *arrow.TimestampType:
+		switch t.Unit {
+		case arrow.Second:
+			return LogicalType{ID: TypeTimestampSec}
+		case arrow.Millisecond:
+			return LogicalType{ID: TypeTimestampMS}
+		case arrow.Nanosecond:
+			return LogicalType{ID: TypeTimestampNS}
+		default:
+			if t.TimeZone != "" {
+				return LogicalType{ID: TypeTimestampTZ}
+			}
+			return LogicalType{ID: TypeTimestamp}
+		}
+	case *arrow.MonthDayNanoIntervalType, *arrow.DurationType:
+		return LogicalType{ID: TypeInterval}
+	case *arrow.Decimal128Type, *arrow.Decimal256Type:
+		return LogicalType{ID: TypeDecimal}
+	case *arrow.ListType:
+		elem := logicalTypeFromArrow(t.Elem())
+		return LogicalType{ID: TypeList, Elem: &elem}
+	case *arrow.LargeListType:
+		elem := logicalTypeFromArrow(t.Elem())
+		return LogicalType{ID: TypeList, Elem: &elem}
+	case *arrow.FixedSizeListType:
+		elem := logicalTypeFromArrow(t.Elem())
+		return LogicalType{ID: TypeArray, Elem: &elem, ArrayLen: uint64(t.Len())}
+	case *arrow.MapType:
+		return LogicalType{ID: TypeMap, Fields: []Field{
+			{Name: "key", Type: logicalTypeFromArrow(t.KeyType())},
+			{Name: "value", Type: logicalTypeFromArrow(t.ItemType())},
+		}}
+	case *arrow.StructType:
+		return LogicalType{ID: TypeStruct, Fields: arrowFields(t.Fields())}
+	case arrow.UnionType:
+		return LogicalType{ID: TypeUnion, Fields: arrowFields(t.Fields())}
+	default:
+		return LogicalType{ID: TypeAny}
+	}
+}
+
+func arrowFields(fs []arrow.Field) []Field {
+	out := make([]Field, len(fs))
+	for i, f := range fs {
+		out[i] = Field{Name: f.Name, Type: logicalTypeFromArrow(f.Type)}
+	}
+	return out
+}